@@ -0,0 +1,347 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/config"
+	"github.com/danielcmessias/sawsy/data"
+	"github.com/danielcmessias/sawsy/ui"
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/ui/context"
+	"github.com/danielcmessias/sawsy/ui/pages/glue"
+	"github.com/danielcmessias/sawsy/ui/pages/iam"
+	"github.com/danielcmessias/sawsy/ui/pages/lakeformation"
+	"github.com/danielcmessias/sawsy/ui/pages/lambda"
+	"github.com/danielcmessias/sawsy/ui/pages/rds"
+	"github.com/danielcmessias/sawsy/ui/pages/s3"
+	"github.com/danielcmessias/sawsy/utils"
+)
+
+// The commands that run without the TUI
+var Commands = []string{"list", "get"}
+
+func IsCommand(arg string) bool {
+	for _, c := range Commands {
+		if arg == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Identifies the resource shown by pages that are about a single one, e.g. an S3 bucket
+type resourceFlags struct {
+	bucket     string
+	prefix     string
+	key        string
+	job        string
+	user       string
+	role       string
+	policyArn  string
+	policyName string
+	instance   string
+	database   string
+	table      string
+	function   string
+}
+
+// Runs a page's fetches without starting the TUI and prints the rows of one of its tables, e.g.
+//
+//	sawsy list rds --output json
+//	sawsy get s3/objects --bucket x --prefix y
+//
+// list and get behave the same, get just reads better for pages about a single resource.
+func Run(args []string, cfg config.Config, clientOptions data.ClientOptions, out io.Writer) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: sawsy %s <page> [flags]", strings.Join(Commands, "|"))
+	}
+	command, pageName := args[0], args[1]
+
+	flags := flag.NewFlagSet("sawsy "+command+" "+pageName, flag.ContinueOnError)
+	output := flags.String("output", "table", "output format: table, "+strings.Join(table.ExportFormats(), ", "))
+	paneName := flags.String("pane", "", "name of the table to print, defaults to the page's first")
+	filter := flags.String("filter", "", "only print rows matching `query`, as when searching a table")
+	limit := flags.Int("limit", 0, "stop after this many rows, 0 for all of them")
+	flags.StringVar(&clientOptions.Profile, "profile", clientOptions.Profile, "AWS profile to use")
+	flags.StringVar(&clientOptions.Region, "region", clientOptions.Region, "AWS region to use")
+
+	var resource resourceFlags
+	flags.StringVar(&resource.bucket, "bucket", "", "S3 bucket, for s3/objects and s3/object")
+	flags.StringVar(&resource.prefix, "prefix", "", "S3 prefix, for s3/objects")
+	flags.StringVar(&resource.key, "key", "", "S3 object key, for s3/object")
+	flags.StringVar(&resource.job, "job", "", "Glue job name, for glue/job")
+	flags.StringVar(&resource.user, "user", "", "IAM user name, for iam/user and inline policies")
+	flags.StringVar(&resource.role, "role", "", "IAM role name, for iam/role and inline policies")
+	flags.StringVar(&resource.policyArn, "policy-arn", "", "managed policy ARN, for iam/policy")
+	flags.StringVar(&resource.policyName, "policy-name", "", "inline policy name, for iam/policy")
+	flags.StringVar(&resource.instance, "instance", "", "RDS instance id, for rds/instance")
+	flags.StringVar(&resource.database, "database", "", "Lake Formation database, for lakeformation/database and lakeformation/table")
+	flags.StringVar(&resource.table, "table", "", "Lake Formation table, for lakeformation/table")
+	flags.StringVar(&resource.function, "function", "", "Lambda function name, for lambda/function")
+
+	if err := flags.Parse(args[2:]); err != nil {
+		return err
+	}
+	if !isOutputFormat(*output) {
+		return fmt.Errorf("unknown output format %q, expected table, %s", *output, strings.Join(table.ExportFormats(), ", "))
+	}
+	format, err := table.NewCellFormat(cfg.Format)
+	if err != nil {
+		return err
+	}
+
+	client, err := data.NewClient(clientOptions)
+	if err != nil {
+		return fmt.Errorf("error creating new data client: %w", err)
+	}
+	awsAccountId, err := client.GetCurrentAWSAccountId()
+	if err != nil {
+		return err
+	}
+
+	ctx := &context.ProgramContext{
+		Config:       &cfg,
+		AwsAccountId: awsAccountId,
+		AwsProfile:   client.GetProfile(),
+		AwsRegion:    client.GetRegion(),
+		AwsService:   pageName,
+		Keys:         utils.DefaultKeys,
+	}
+	p, err := findPage(ctx, pageName)
+	if err != nil {
+		return err
+	}
+	pageContext, err := newPageContext(pageName, resource)
+	if err != nil {
+		return err
+	}
+	p.SetPageContext(pageContext)
+
+	paneId, err := findTable(p, *paneName)
+	if err != nil {
+		return err
+	}
+
+	tableColumns := p.GetSpec().PaneSpecs[paneId].(table.TableSpec).Columns
+	query, err := table.ParseQuery(*filter, tableColumns)
+	if err != nil {
+		return fmt.Errorf("invalid --filter: %w", err)
+	}
+
+	rows, err := fetchRows(client, p, paneId, query, format, *limit)
+	if err != nil {
+		return err
+	}
+
+	var columns []string
+	for _, c := range tableColumns {
+		columns = append(columns, c.Title)
+	}
+	return writeRows(out, *output, columns, rows, format)
+}
+
+func findPage(ctx *context.ProgramContext, pageName string) (page.Page, error) {
+	var names []string
+	for _, p := range ui.NewPages(ctx) {
+		if p.GetSpec().Name == pageName {
+			return p, nil
+		}
+		names = append(names, p.GetSpec().Name)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("no page named %q, expected one of: %s", pageName, strings.Join(names, ", "))
+}
+
+// The id of the table pane with the given name, or the page's first table if name is empty
+func findTable(p page.Page, name string) (int, error) {
+	var names []string
+	for i, spec := range p.GetSpec().PaneSpecs {
+		if _, ok := spec.(table.TableSpec); !ok {
+			continue
+		}
+		if name == "" || strings.EqualFold(spec.GetName(), name) {
+			return i, nil
+		}
+		names = append(names, spec.GetName())
+	}
+	if name == "" {
+		return 0, fmt.Errorf("page %s has no tables", p.GetSpec().Name)
+	}
+	return 0, fmt.Errorf("page %s has no table named %q, expected one of: %s", p.GetSpec().Name, name, strings.Join(names, ", "))
+}
+
+func newPageContext(pageName string, f resourceFlags) (interface{}, error) {
+	switch pageName {
+	case "glue/job":
+		return glue.JobPageContext{JobName: f.job}, required("job", f.job)
+
+	case "iam/user":
+		return iam.UserPageContext{UserName: f.user}, required("user", f.user)
+	case "iam/role":
+		return iam.RolePageContext{RoleName: f.role}, required("role", f.role)
+	case "iam/policy":
+		if f.policyArn == "" && (f.policyName == "" || (f.user == "" && f.role == "")) {
+			return nil, errors.New("--policy-arn, or --policy-name with --user or --role, is required")
+		}
+		return iam.PolicyPageContext{
+			PolicyArn:  f.policyArn,
+			PolicyName: f.policyName,
+			UserName:   f.user,
+			RoleName:   f.role,
+		}, nil
+
+	case "lakeformation/database":
+		return lakeformation.DatabasePageContext{DatabaseName: f.database}, required("database", f.database)
+	case "lakeformation/table":
+		return lakeformation.TablePageContext{
+			DatabaseName: f.database,
+			TableName:    f.table,
+		}, required("database", f.database, "table", f.table)
+
+	case "lambda/function":
+		return lambda.FunctionPageContext{FunctionName: f.function}, required("function", f.function)
+
+	case "rds/instance":
+		return rds.InstancePageContext{InstanceId: f.instance}, required("instance", f.instance)
+
+	case "s3/objects", "s3/object":
+		// The bucket's region is looked up when it's first needed
+		if pageName == "s3/objects" {
+			return s3.BucketPageContext{Bucket: f.bucket, Prefix: f.prefix}, required("bucket", f.bucket)
+		}
+		return s3.ObjectPageContext{Bucket: f.bucket, Key: f.key}, required("bucket", f.bucket, "key", f.key)
+	}
+	return nil, nil
+}
+
+// Takes pairs of flag names and values, returning an error for the first value that's empty
+func required(flagsAndValues ...string) error {
+	for i := 0; i < len(flagsAndValues); i += 2 {
+		if flagsAndValues[i+1] == "" {
+			return fmt.Errorf("--%s is required", flagsAndValues[i])
+		}
+	}
+	return nil
+}
+
+// Fetches every page of rows for a table, stopping early once limit rows match the filter
+func fetchRows(client *data.Client, p page.Page, paneId int, query *table.Query, format table.CellFormat, limit int) ([]table.Row, error) {
+	c := collector{paneId: paneId}
+
+	cmd := p.FetchNextPage(client, paneId, nil)
+	// Not paginated, so the only way to get its rows is with the rest of the page's
+	if cmd == nil {
+		cmd = p.FetchData(client)
+	}
+	c.run(cmd)
+
+	for c.err == nil && c.nextToken != nil && (limit == 0 || len(matching(c.rows, query, format)) < limit) {
+		nextToken := c.nextToken
+		c.nextToken = nil
+		c.run(p.FetchNextPage(client, paneId, nextToken))
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	rows := matching(c.rows, query, format)
+	if limit > 0 && len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return rows, nil
+}
+
+// Searches look at cells as they'd be shown in the UI
+func matching(rows []table.Row, query *table.Query, format table.CellFormat) []table.Row {
+	if query == nil {
+		return rows
+	}
+	var matched []table.Row
+	for _, r := range rows {
+		if query.Matches(r, format.FormatRow(r)) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// Runs the commands a page would have the TUI run, keeping what they fetch for one pane
+type collector struct {
+	mu        sync.Mutex
+	paneId    int
+	rows      []table.Row
+	nextToken *string
+	err       error
+}
+
+func (c *collector) run(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	msg := cmd()
+	if cmds, ok := utils.Unbatch(msg); ok {
+		var wg sync.WaitGroup
+		for _, cmd := range cmds {
+			wg.Add(1)
+			go func(cmd tea.Cmd) {
+				defer wg.Done()
+				c.run(cmd)
+			}(cmd)
+		}
+		wg.Wait()
+		return
+	}
+	c.run(c.handle(msg))
+}
+
+func (c *collector) handle(msg tea.Msg) tea.Cmd {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch msg := msg.(type) {
+	case page.NewRowsMsg:
+		if msg.PaneId != c.paneId {
+			return nil
+		}
+		if msg.Overwrite {
+			c.rows = nil
+		}
+		c.rows = append(c.rows, msg.Rows...)
+		c.nextToken = msg.NextToken
+		return msg.NextCmd
+
+	case page.BatchedNewRowsMsg:
+		var cmds []tea.Cmd
+		for _, m := range msg.Msgs {
+			if m.PaneId == c.paneId {
+				c.rows = append(c.rows, m.Rows...)
+				cmds = append(cmds, m.NextCmd)
+			}
+		}
+		return tea.Batch(cmds...)
+
+	case page.UpdateRowMsg:
+		if msg.PaneId != c.paneId {
+			return nil
+		}
+		for i, row := range c.rows {
+			if row[msg.PrimaryKeyIndex] == msg.Row[msg.PrimaryKeyIndex] {
+				c.rows[i] = msg.Row
+			}
+		}
+
+	case page.FetchErrorMsg:
+		if msg.PaneId == c.paneId && c.err == nil {
+			c.err = msg.Err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/danielcmessias/sawsy/ui/components/table"
+)
+
+// Plain aligned columns for reading in a terminal, with cells shown as in the UI. The other formats
+// are the ones tables can be exported in.
+func writeTable(out io.Writer, columns []string, rows []table.Row, format table.CellFormat) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+	// Tabs and newlines would break the alignment
+	clean := strings.NewReplacer("\t", " ", "\n", " ")
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for j := range columns {
+			if j < len(row) {
+				record[j] = clean.Replace(format.Format(row[j]))
+			}
+		}
+		fmt.Fprintln(w, strings.Join(record, "\t"))
+	}
+	return w.Flush()
+}
+
+func writeRows(out io.Writer, output string, columns []string, rows []table.Row, format table.CellFormat) error {
+	if output == "table" {
+		return writeTable(out, columns, rows, format)
+	}
+	return table.WriteRows(out, output, columns, rows)
+}
+
+func isOutputFormat(format string) bool {
+	if format == "table" {
+		return true
+	}
+	for _, f := range table.ExportFormats() {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
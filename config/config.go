@@ -1,38 +1,228 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Theme ThemeConfig `yaml:"theme"`
+	Theme  ThemeConfig  `yaml:"theme"`
+	Format FormatConfig `yaml:"format"`
+	Cache  CacheConfig  `yaml:"cache"`
+	AWS    AWSConfig    `yaml:"aws"`
+	// Keyed by page and pane name, e.g. "s3/objects/Objects"
+	Tables map[string]TableConfig `yaml:"tables"`
+	// Replaces the keys of bindings, keyed by binding, e.g. quit: [q, ctrl+c]
+	Keys map[string]KeyList `yaml:"keys"`
+	// Pages saved under a name, kept here so a team can share them
+	Bookmarks []Bookmark `yaml:"bookmarks"`
 }
 
 type ThemeConfig struct {
 	ShowIcons bool `yaml:"showIcons"`
+	// A built-in theme, e.g. dracula, or the path of a theme file
+	Name string `yaml:"name"`
+	// Override the theme's, in the same way as a theme file
+	CodeStyle string                `yaml:"codeStyle"`
+	Colors    map[string]ThemeColor `yaml:"colors"`
 }
 
+// How values in tables are shown
+type FormatConfig struct {
+	// A Go time layout (e.g. "2006-01-02 15:04"), or "relative" for e.g. "3h ago"
+	Time string `yaml:"time"`
+	// e.g. UTC, Local or Europe/London. Times are shown as AWS gives them, mostly UTC, if unset.
+	TimeZone string `yaml:"timeZone"`
+	// Sizes in bytes, rather than e.g. 1.2 MB
+	RawSizes bool `yaml:"rawSizes"`
+}
+
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Keyed by service (e.g. s3, glue, iam), or "default" for the rest. 0s turns caching off.
+	TTL map[string]time.Duration `yaml:"ttl"`
+}
+
+// Mostly for pointing sawsy at an emulator such as LocalStack or moto
+type AWSConfig struct {
+	// Keyed by service (e.g. s3, glue, iam), or "default" for all of them
+	Endpoints   map[string]string `yaml:"endpoints"`
+	S3PathStyle bool              `yaml:"s3PathStyle"`
+	// For emulators without STS. accountId is shown instead of looking it up.
+	SkipSTS   bool   `yaml:"skipSts"`
+	AccountId string `yaml:"accountId"`
+}
+
+// One key, or a list of them
+type KeyList []string
+
+func (l *KeyList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = KeyList{value.Value}
+		return nil
+	}
+	return value.Decode((*[]string)(l))
+}
+
+// A page saved under a name, to get back to from the bookmarks page
+type Bookmark struct {
+	Name string `yaml:"name"`
+	// Written the way the go to prompt reads it, e.g. s3://bucket/prefix/ or iam/role my-role
+	Link string `yaml:"link"`
+	// Of an S3 bucket, saves looking it up again
+	Region string `yaml:"region,omitempty"`
+}
+
+// How a table's columns are laid out, changed from inside sawsy
+type TableConfig struct {
+	// Column titles in the order they're shown, any not listed come after in their usual order
+	Columns []string `yaml:"columns,omitempty"`
+	Hidden  []string `yaml:"hidden,omitempty"`
+}
+
+func path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".sawsy.yml"), nil
+}
+
+// Reads the config file over the defaults. There being no file isn't an error.
 func ReadConfig() (Config, error) {
 	config := getDefaultConfig()
 
-	homeDir, err := os.UserHomeDir()
+	path, err := path()
 	if err != nil {
 		return config, err
 	}
-	path := filepath.Join(homeDir, ".sawsy.yml")
 
 	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
 	if err != nil {
 		return config, err
 	}
 
 	err = yaml.Unmarshal(data, &config)
 	if err != nil {
-		return config, err
+		return config, fmt.Errorf("%s: %w", path, err)
 	}
 
 	return config, nil
 }
+
+// Writes the layout of one table to the config file, leaving the rest of the file as it was. A nil
+// table removes it.
+func SaveTableConfig(key string, table *TableConfig) error {
+	return updateConfig(func(root *yaml.Node) error {
+		tables := mappingValue(root, "tables")
+		if table == nil {
+			removeMappingValue(tables, key)
+			return nil
+		}
+		value := &yaml.Node{}
+		if err := value.Encode(table); err != nil {
+			return err
+		}
+		*mappingValue(tables, key) = *value
+		return nil
+	})
+}
+
+// Replaces the bookmarks in the config file, leaving the rest of the file as it was
+func SaveBookmarks(bookmarks []Bookmark) error {
+	return updateConfig(func(root *yaml.Node) error {
+		if len(bookmarks) == 0 {
+			removeMappingValue(root, "bookmarks")
+			return nil
+		}
+		value := &yaml.Node{}
+		if err := value.Encode(bookmarks); err != nil {
+			return err
+		}
+		*mappingValue(root, "bookmarks") = *value
+		return nil
+	})
+}
+
+// Held while the config file is read and written back, so that saves made at the same time from
+// different commands don't undo each other
+var updateMu sync.Mutex
+
+// Rewrites the config file with whatever update changes in its top level mapping, keeping the
+// comments and order of everything else
+func updateConfig(update func(root *yaml.Node) error) error {
+	updateMu.Lock()
+	defer updateMu.Unlock()
+
+	path, err := path()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode}},
+		}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s isn't a mapping", path)
+	}
+
+	if err := update(root); err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	return os.WriteFile(path, out.Bytes(), 0644)
+}
+
+// The mapping under key, added if it isn't there or isn't a mapping
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			value := mapping.Content[i+1]
+			if value.Kind != yaml.MappingNode {
+				*value = yaml.Node{Kind: yaml.MappingNode}
+			}
+			return value
+		}
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
+
+func removeMappingValue(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -1,9 +1,19 @@
 package config
 
+import "time"
+
 func getDefaultConfig() Config {
 	return Config{
 		Theme: ThemeConfig{
 			ShowIcons: true,
 		},
+		Cache: CacheConfig{
+			Enabled: true,
+			TTL: map[string]time.Duration{
+				"default":    24 * time.Hour,
+				"s3":         time.Hour,
+				"cloudwatch": 0,
+			},
+		},
 	}
 }
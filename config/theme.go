@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A theme kept in a file of its own, e.g.
+//
+//	base: dracula
+//	codeStyle: monokai
+//	colors:
+//	  mainText: "#f8f8f2"
+//	  border: {light: "#dce0e8", dark: "#44475a"}
+type ThemeFile struct {
+	// The theme the file changes, the default if empty
+	Base string `yaml:"base"`
+	// How code is highlighted, a chroma style (e.g. monokai) or a glamour one (e.g. dark)
+	CodeStyle string `yaml:"codeStyle"`
+	// Keyed by the theme's colors, e.g. mainText or highlightRow
+	Colors map[string]ThemeColor `yaml:"colors"`
+}
+
+// A color for terminals with light and dark backgrounds, each a hex color or an ANSI color number.
+// Written as just the one when they're the same.
+type ThemeColor struct {
+	Light string `yaml:"light"`
+	Dark  string `yaml:"dark"`
+}
+
+func (c *ThemeColor) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		c.Light = value.Value
+		c.Dark = value.Value
+		return nil
+	}
+	type plain ThemeColor
+	return value.Decode((*plain)(c))
+}
+
+// Whether a theme name is the path of a theme file rather than a built-in theme
+func IsThemeFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return strings.ContainsRune(name, filepath.Separator) || ext == ".yml" || ext == ".yaml"
+}
+
+func ReadThemeFile(path string) (ThemeFile, error) {
+	var theme ThemeFile
+	if strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return theme, err
+		}
+		path = filepath.Join(homeDir, path[2:])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return theme, fmt.Errorf("error reading theme: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &theme); err != nil {
+		return theme, fmt.Errorf("error reading theme %s: %w", path, err)
+	}
+	return theme, nil
+}
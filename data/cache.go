@@ -0,0 +1,158 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+type CacheOptions struct {
+	Enabled bool
+	// How long responses stay usable, by lowercase service id (e.g. "s3", "lakeformation"), with
+	// "default" used for services not listed. A zero TTL disables caching for that service.
+	TTLs map[string]time.Duration
+}
+
+// Returned by a client reading from the cache only, when it has nothing usable for a request
+var ErrCacheMiss error = missError("response not in cache")
+
+// Persists raw API responses on disk, keyed by account and request, so that a later session can
+// show them while it waits for fresh ones.
+//
+// Responses are stored and served by the HTTP client rather than a middleware, so that the SDK
+// deserializes them exactly as it would a live response.
+type responseCache struct {
+	dir       string
+	ttls      map[string]time.Duration
+	accountId string
+	http      aws.HTTPClient
+}
+
+type cacheOnlyKey struct{}
+
+// Never written to disk, whatever the TTLs, because their responses can hold secrets: Lambda
+// environment variables, Glue job arguments, object contents and policy documents. Keyed by service
+// name and operation.
+var uncachedOperations = map[string]bool{
+	"lambda/GetFunction":   true,
+	"lambda/ListFunctions": true,
+	"glue/GetJob":          true,
+	"glue/GetJobRuns":      true,
+	"iam/GetRole":          true,
+	"iam/ListRoles":        true,
+	"iam/GetRolePolicy":    true,
+	"iam/GetUserPolicy":    true,
+	"iam/GetPolicyVersion": true,
+	"s3/GetObject":         true,
+	"s3/GetBucketPolicy":   true,
+}
+
+func newResponseCache(opts CacheOptions, client aws.HTTPClient) (*responseCache, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("error finding cache directory: %w", err)
+	}
+	return &responseCache{
+		dir:  filepath.Join(dir, "sawsy", "responses"),
+		ttls: opts.TTLs,
+		http: client,
+	}, nil
+}
+
+func (c *responseCache) ttl(key requestKey) time.Duration {
+	service := serviceName(key.Service)
+	// The caller's identity is what keys everything else
+	if service == "sts" || uncachedOperations[service+"/"+key.Operation] {
+		return 0
+	}
+	if ttl, ok := c.ttls[service]; ok {
+		return ttl
+	}
+	return c.ttls["default"]
+}
+
+func (c *responseCache) Do(req *http.Request) (*http.Response, error) {
+	key, keyed := getRequestKey(req.Context())
+	ttl := c.ttl(key)
+	// Responses are only cached once we know which account they belong to
+	cacheable := keyed && ttl > 0 && c.accountId != ""
+
+	if cacheOnly, _ := req.Context().Value(cacheOnlyKey{}).(bool); cacheOnly {
+		if !cacheable {
+			return nil, ErrCacheMiss
+		}
+		return c.load(req, key, ttl)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil || !cacheable || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, err
+	}
+
+	stored, err := newStoredResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	// Failing to cache shouldn't fail the request
+	_ = c.store(key, stored)
+	return resp, nil
+}
+
+func (c *responseCache) path(key requestKey) string {
+	h := sha256.Sum256([]byte(c.accountId + "\n" + key.Hash))
+	return filepath.Join(c.dir, hex.EncodeToString(h[:])+".json")
+}
+
+func (c *responseCache) load(req *http.Request, key requestKey, ttl time.Duration) (*http.Response, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, ErrCacheMiss
+	}
+	var stored storedResponse
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, ErrCacheMiss
+	}
+	if time.Since(stored.StoredAt) > ttl {
+		os.Remove(c.path(key))
+		return nil, ErrCacheMiss
+	}
+	return stored.toHTTP(req), nil
+}
+
+func (c *responseCache) store(key requestKey, resp storedResponse) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so another session never reads half a response
+	path := c.path(key)
+	tmp, err := os.CreateTemp(c.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func withCacheOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheOnlyKey{}, true)
+}
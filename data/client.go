@@ -3,7 +3,10 @@ package data
 import (
 	"context"
 	"fmt"
+	"os"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/glue"
@@ -17,9 +20,35 @@ import (
 
 const MAX_RESULTS = 32
 
+type ClientOptions struct {
+	// Empty values fall back to the SDK's usual resolution (environment variables, then the
+	// default profile)
+	Profile string
+	Region  string
+	Cache   CacheOptions
+
+	// Every response received is appended to this recording, if set
+	Recorder *Recorder
+	// Responses are served from this recording instead of AWS, if set
+	Replay *Replay
+
+	// Endpoint URLs keyed by service name (e.g. "s3", "lakeformation"), or "default" for all
+	// services, to use an emulator such as LocalStack instead of AWS
+	Endpoints   map[string]string
+	S3PathStyle bool
+	// Don't look up the caller's identity with STS, for emulators that don't support it.
+	// AccountId is shown instead (UNKNOWN_ACCOUNT_ID if empty).
+	SkipSTS   bool
+	AccountId string
+}
+
 type Client struct {
-	ctx context.Context
-	sts *sts.Client
+	ctx       context.Context
+	sts       *sts.Client
+	profile   string
+	region    string
+	accountId string
+	cache     *responseCache
 
 	Glue          *GlueClient
 	IAM           *IAMClient
@@ -29,24 +58,87 @@ type Client struct {
 	S3            *S3Client
 }
 
-func NewClient() (*Client, error) {
-	ctx := context.TODO()
-	cfg, err := config.LoadDefaultConfig(ctx)
+func NewClient(options ClientOptions) (*Client, error) {
+	ctx := context.Background()
+	profile := options.Profile
+	region := options.Region
+	// Requests are only found in a recording if they're for the region it was made in
+	if region == "" && options.Replay != nil {
+		region = options.Replay.region
+	}
+
+	var opts []func(*config.LoadOptions) error
+	if profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(profile))
+	}
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("error when loading SDK config: %w", err)
 	}
 
+	if len(options.Endpoints) > 0 {
+		cfg.EndpointResolverWithOptions = newEndpointResolver(options.Endpoints)
+	}
+
+	var cache *responseCache
+	if options.Cache.Enabled || options.Recorder != nil || options.Replay != nil {
+		addRequestKeyMiddleware(&cfg)
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = awshttp.NewBuildableClient()
+	}
+	if options.Replay != nil {
+		// Nothing is sent, so there's nothing to sign either
+		cfg.Credentials = aws.AnonymousCredentials{}
+		cfg.HTTPClient = options.Replay
+	}
+	if options.Recorder != nil {
+		cfg.HTTPClient = &recordingHTTPClient{recorder: options.Recorder, http: cfg.HTTPClient}
+	}
+	if options.Cache.Enabled {
+		cache, err = newResponseCache(options.Cache, cfg.HTTPClient)
+		if err != nil {
+			return nil, err
+		}
+		cfg.HTTPClient = cache
+	}
+
+	if profile == "" {
+		profile = os.Getenv("AWS_PROFILE")
+	}
+	if profile == "" {
+		profile = DEFAULT_PROFILE
+	}
+
 	cloudwatch := cloudwatch.NewFromConfig(cfg)
 	glue := glue.NewFromConfig(cfg)
 	iam := iam.NewFromConfig(cfg)
 	lakeformation := lakeformation.NewFromConfig(cfg)
 	lambda := lambda.NewFromConfig(cfg)
 	rds := rds.NewFromConfig(cfg)
-	s3 := s3.NewFromConfig(cfg)
+	s3 := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = options.S3PathStyle
+	})
+
+	// An empty account id means it's looked up with STS
+	var accountId string
+	if options.SkipSTS {
+		accountId = options.AccountId
+		if accountId == "" {
+			accountId = UNKNOWN_ACCOUNT_ID
+		}
+	}
 
 	return &Client{
-		ctx: ctx,
-		sts: sts.NewFromConfig(cfg),
+		ctx:       ctx,
+		sts:       sts.NewFromConfig(cfg),
+		profile:   profile,
+		region:    cfg.Region,
+		accountId: accountId,
+		cache:     cache,
 
 		Glue:          NewGlueClient(ctx, glue, s3),
 		IAM:           NewIAMClient(ctx, iam),
@@ -57,11 +149,55 @@ func NewClient() (*Client, error) {
 	}, nil
 }
 
+// Returns a copy of the client whose requests all use ctx, so they can be cancelled together
+func (c *Client) WithContext(ctx context.Context) *Client {
+	return &Client{
+		ctx:       ctx,
+		sts:       c.sts,
+		profile:   c.profile,
+		region:    c.region,
+		accountId: c.accountId,
+		cache:     c.cache,
+
+		Glue:          NewGlueClient(ctx, c.Glue.glue, c.Glue.s3),
+		IAM:           NewIAMClient(ctx, c.IAM.iam),
+		LakeFormation: NewLakeFormationClient(ctx, c.LakeFormation.lf, c.LakeFormation.glue),
+		Lambda:        NewLambdaClient(ctx, c.Lambda.lambda, c.Lambda.cloudwatch),
+		RDS:           NewRDSClient(ctx, c.RDS.rds, c.RDS.cloudwatch),
+		S3:            NewS3Client(ctx, c.S3.s3),
+	}
+}
+
 func (c *Client) GetCurrentAWSAccountId() (string, error) {
-	input := sts.GetCallerIdentityInput{}
-	output, err := c.sts.GetCallerIdentity(c.ctx, &input)
-	if err != nil {
-		return "", fmt.Errorf("error getting AWS Account ID: %w", err)
+	accountId := c.accountId
+	if accountId == "" {
+		input := sts.GetCallerIdentityInput{}
+		output, err := c.sts.GetCallerIdentity(c.ctx, &input)
+		if err != nil {
+			return "", fmt.Errorf("error getting AWS Account ID: %w", err)
+		}
+		accountId = *output.Account
 	}
-	return *output.Account, nil
+	// Responses are only cached once we know which account they belong to
+	if c.cache != nil {
+		c.cache.accountId = accountId
+	}
+	return accountId, nil
+}
+
+// Returns a copy of the client that answers from the response cache only, failing with
+// ErrCacheMiss instead of calling AWS. Returns nil if caching is disabled.
+func (c *Client) FromCache() *Client {
+	if c.cache == nil {
+		return nil
+	}
+	return c.WithContext(withCacheOnly(c.ctx))
+}
+
+func (c *Client) GetProfile() string {
+	return c.profile
+}
+
+func (c *Client) GetRegion() string {
+	return c.region
 }
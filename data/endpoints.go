@@ -0,0 +1,37 @@
+package data
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// The account shown when the caller's identity can't be looked up, see ClientOptions.SkipSTS
+const UNKNOWN_ACCOUNT_ID = "local"
+
+// Lowercase service id without spaces, e.g. "lakeformation" for "LakeFormation", as used to key
+// per-service settings
+func serviceName(serviceId string) string {
+	return strings.ToLower(strings.ReplaceAll(serviceId, " ", ""))
+}
+
+// Resolves services to the given endpoints, keyed by service name or "default" for any service not
+// listed. Services without an endpoint fall back to the SDK's usual resolution.
+func newEndpointResolver(endpoints map[string]string) aws.EndpointResolverWithOptions {
+	return aws.EndpointResolverWithOptionsFunc(
+		func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+			url, ok := endpoints[serviceName(service)]
+			if !ok {
+				url, ok = endpoints["default"]
+			}
+			if !ok || url == "" {
+				return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+			}
+			return aws.Endpoint{
+				URL:               url,
+				SigningRegion:     region,
+				HostnameImmutable: true,
+			}, nil
+		},
+	)
+}
@@ -0,0 +1,37 @@
+package data
+
+import (
+	"errors"
+
+	"github.com/aws/smithy-go"
+)
+
+// Details pulled out of an AWS SDK error so they can be shown to the user
+type ErrorDetails struct {
+	Code      string
+	Message   string
+	RequestId string
+}
+
+type requestIdError interface {
+	ServiceRequestID() string
+}
+
+func GetErrorDetails(err error) ErrorDetails {
+	details := ErrorDetails{
+		Message: err.Error(),
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		details.Code = apiErr.ErrorCode()
+		details.Message = apiErr.ErrorMessage()
+	}
+
+	var reqErr requestIdError
+	if errors.As(err, &reqErr) {
+		details.RequestId = reqErr.ServiceRequestID()
+	}
+
+	return details
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"time"
 
 	aws "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/glue"
@@ -49,14 +50,14 @@ func (c *GlueClient) GetJobsRows(nextToken *string) ([]table.Row, *string, error
 
 	var rows []table.Row
 	for _, j := range getOutput.Jobs {
-		rows = append(rows, table.Row{
+		rows = append(rows, table.WithObject(table.Row{
 			aws.ToString(j.Name),
 			aws.ToString(j.Command.Name),
-			formatTime(j.LastModifiedOn),
+			timeCell(j.LastModifiedOn),
 			aws.ToString(j.GlueVersion),
 			string(j.WorkerType),
-			fmt.Sprintf("%d", aws.ToInt32(j.NumberOfWorkers)),
-		})
+			aws.ToInt32(j.NumberOfWorkers),
+		}, j))
 	}
 
 	return rows, listOutput.NextToken, nil
@@ -104,13 +105,13 @@ func (c *GlueClient) GetCrawlersRows(nextToken *string) ([]table.Row, *string, e
 			schedule = aws.ToString(c.Schedule.ScheduleExpression)
 		}
 
-		rows = append(rows, table.Row{
+		rows = append(rows, table.WithObject(table.Row{
 			aws.ToString(c.Name),
 			schedule,
 			string(c.State),
-			formatSeconds(int(metrics.LastRuntimeSeconds)),
-			formatSeconds(int(metrics.MedianRuntimeSeconds)),
-		})
+			secondsCell(metrics.LastRuntimeSeconds),
+			secondsCell(metrics.MedianRuntimeSeconds),
+		}, c))
 	}
 
 	return rows, listOutput.NextToken, nil
@@ -133,14 +134,14 @@ func (c *GlueClient) GetJobDetails(jobName string) ([]table.Row, error) {
 		{"Role", aws.ToString(job.Role)},
 		{"Glue Version", aws.ToString(job.GlueVersion)},
 		{"Worker Type", string(job.WorkerType)},
-		{"# Workers", fmt.Sprintf("%d", aws.ToInt32(job.NumberOfWorkers))},
-		{"Max Retries", fmt.Sprintf("%d", job.MaxRetries)},
-		{"Timeout", fmt.Sprintf("%dm", aws.ToInt32(job.Timeout))},
+		{"# Workers", aws.ToInt32(job.NumberOfWorkers)},
+		{"Max Retries", job.MaxRetries},
+		{"Timeout", time.Duration(aws.ToInt32(job.Timeout)) * time.Minute},
 		{"Script", aws.ToString(job.Command.ScriptLocation)},
-		{"Created On", formatTime(job.CreatedOn)},
-		{"Last Modified", formatTime(job.LastModifiedOn)},
+		{"Created On", timeCell(job.CreatedOn)},
+		{"Last Modified", timeCell(job.LastModifiedOn)},
 	}
-	return rows, nil
+	return withObject(rows, job), nil
 }
 
 func (c *GlueClient) GetJobRuns(jobName string) ([]table.Row, error) {
@@ -154,12 +155,12 @@ func (c *GlueClient) GetJobRuns(jobName string) ([]table.Row, error) {
 
 	var rows []table.Row
 	for _, r := range output.JobRuns {
-		rows = append(rows, table.Row{
-			formatTime(r.StartedOn),
+		rows = append(rows, table.WithObject(table.Row{
+			timeCell(r.StartedOn),
 			string(r.JobRunState),
-			fmt.Sprintf("%d", aws.ToInt32(&r.Attempt)),
-			formatSeconds(int(r.ExecutionTime)),
-		})
+			r.Attempt,
+			secondsCell(float64(r.ExecutionTime)),
+		}, r))
 	}
 
 	return rows, nil
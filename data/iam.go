@@ -33,17 +33,17 @@ func (c *IAMClient) GetUsers(nextToken *string) ([]table.Row, *string, error) {
 
 	var rows []table.Row
 	for _, u := range output.Users {
-		lastUsed := "None"
+		var lastUsed table.Cell = "None"
 		if u.PasswordLastUsed != nil {
-			lastUsed = formatTime(u.PasswordLastUsed)
+			lastUsed = *u.PasswordLastUsed
 		}
 
-		rows = append(rows, table.Row{
+		rows = append(rows, table.WithObject(table.Row{
 			aws.ToString(u.UserName),
-			aws.ToString(u.Arn),
+			table.ARN(aws.ToString(u.Arn)),
 			lastUsed,
-			formatTime(u.CreateDate),
-		})
+			timeCell(u.CreateDate),
+		}, u))
 	}
 
 	return rows, output.Marker, nil
@@ -60,23 +60,35 @@ func (c *IAMClient) GetRoles(nextToken *string) ([]table.Row, *string, error) {
 
 	var rows []table.Row
 	for _, r := range output.Roles {
-		rows = append(rows, table.Row{
+		rows = append(rows, table.WithObject(table.Row{
 			aws.ToString(r.RoleName),
-			aws.ToString(r.Arn),
-			formatTime(r.CreateDate),
-		})
+			table.ARN(aws.ToString(r.Arn)),
+			timeCell(r.CreateDate),
+		}, r))
 	}
 
 	return rows, output.Marker, nil
 }
 
 func (c *IAMClient) GetUserPolicies(userName string, nextToken *string) ([]table.Row, *string, error) {
-	inputAttached := iam.ListAttachedUserPoliciesInput{
-		UserName: aws.String(userName),
-	}
-	outputAttached, err := c.iam.ListAttachedUserPolicies(c.ctx, &inputAttached)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error listing IAM policies attached to user %s: %w", userName, err)
+	var rows []table.Row
+
+	// Attached policies aren't paginated along with the inline ones, only list them once
+	if nextToken == nil {
+		inputAttached := iam.ListAttachedUserPoliciesInput{
+			UserName: aws.String(userName),
+		}
+		outputAttached, err := c.iam.ListAttachedUserPolicies(c.ctx, &inputAttached)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error listing IAM policies attached to user %s: %w", userName, err)
+		}
+		for _, p := range outputAttached.AttachedPolicies {
+			rows = append(rows, table.WithObject(table.Row{
+				aws.ToString(p.PolicyName),
+				"Attached",
+				table.ARN(aws.ToString(p.PolicyArn)),
+			}, p))
+		}
 	}
 
 	inputInline := iam.ListUserPoliciesInput{
@@ -88,14 +100,6 @@ func (c *IAMClient) GetUserPolicies(userName string, nextToken *string) ([]table
 		return nil, nil, fmt.Errorf("error listing inline IAM policies for user %s: %w", userName, err)
 	}
 
-	var rows []table.Row
-	for _, p := range outputAttached.AttachedPolicies {
-		rows = append(rows, table.Row{
-			aws.ToString(p.PolicyName),
-			"Attached",
-			aws.ToString(p.PolicyArn),
-		})
-	}
 	for _, p := range outputInline.PolicyNames {
 		rows = append(rows, table.Row{
 			p,
@@ -129,12 +133,24 @@ func (c *IAMClient) GetUserTags(userName string, nextToken *string) ([]table.Row
 }
 
 func (c *IAMClient) GetRolePolicies(roleName string, nextToken *string) ([]table.Row, *string, error) {
-	inputAttached := iam.ListAttachedRolePoliciesInput{
-		RoleName: aws.String(roleName),
-	}
-	outputAttached, err := c.iam.ListAttachedRolePolicies(c.ctx, &inputAttached)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error listing IAM policies attached to role %s: %w", roleName, err)
+	var rows []table.Row
+
+	// Attached policies aren't paginated along with the inline ones, only list them once
+	if nextToken == nil {
+		inputAttached := iam.ListAttachedRolePoliciesInput{
+			RoleName: aws.String(roleName),
+		}
+		outputAttached, err := c.iam.ListAttachedRolePolicies(c.ctx, &inputAttached)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error listing IAM policies attached to role %s: %w", roleName, err)
+		}
+		for _, p := range outputAttached.AttachedPolicies {
+			rows = append(rows, table.WithObject(table.Row{
+				aws.ToString(p.PolicyName),
+				"Attached",
+				table.ARN(aws.ToString(p.PolicyArn)),
+			}, p))
+		}
 	}
 
 	inputInline := iam.ListRolePoliciesInput{
@@ -146,14 +162,6 @@ func (c *IAMClient) GetRolePolicies(roleName string, nextToken *string) ([]table
 		return nil, nil, fmt.Errorf("error listing inline IAM policies for role %s: %w", roleName, err)
 	}
 
-	var rows []table.Row
-	for _, p := range outputAttached.AttachedPolicies {
-		rows = append(rows, table.Row{
-			aws.ToString(p.PolicyName),
-			"Attached",
-			aws.ToString(p.PolicyArn),
-		})
-	}
 	for _, p := range outputInline.PolicyNames {
 		rows = append(rows, table.Row{
 			p,
@@ -3,7 +3,6 @@ package data
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 
 	aws "github.com/aws/aws-sdk-go-v2/aws"
@@ -56,7 +55,7 @@ func (c *LakeFormationClient) GetDatabases(nextToken *string) ([]table.Row, *str
 			row = append(row, "", "")
 		}
 
-		rows = append(rows, row)
+		rows = append(rows, table.WithObject(row, d))
 	}
 	return rows, output.NextToken, nil
 }
@@ -102,7 +101,7 @@ func (c *LakeFormationClient) GetTables(nextToken *string) ([]table.Row, *string
 			row = append(row, "", "")
 		}
 
-		rows = append(rows, row)
+		rows = append(rows, table.WithObject(row, t))
 	}
 	return rows, output.NextToken, nil
 }
@@ -128,7 +127,7 @@ func (c *LakeFormationClient) GetDatabaseTables(databaseName string) ([]table.Ro
 			aws.ToString(t.DatabaseName),
 			s3Path,
 		}
-		rows = append(rows, row)
+		rows = append(rows, table.WithObject(row, t))
 	}
 
 	return rows, nil
@@ -154,7 +153,7 @@ func (c *LakeFormationClient) GetLFTags(nextToken *string) ([]table.Row, *string
 			strings.Join(t.TagValues, ","),
 			*t.CatalogId,
 		}
-		rows = append(rows, row)
+		rows = append(rows, table.WithObject(row, t))
 	}
 	return rows, output.NextToken, nil
 }
@@ -191,7 +190,7 @@ func (c *LakeFormationClient) GetLFTagPermissions(nextToken *string) ([]table.Ro
 			perms.String(),
 			grantable.String(),
 		}
-		rows = append(rows, row)
+		rows = append(rows, table.WithObject(row, p))
 	}
 	return rows, output.NextToken, nil
 }
@@ -211,7 +210,7 @@ func (c *LakeFormationClient) GetDataLakeLocations(nextToken *string) ([]table.R
 
 	var rows []table.Row
 	for _, r := range output.ResourceInfoList {
-		rows = append(rows, []string{*r.ResourceArn, r.LastModified.String()})
+		rows = append(rows, table.WithObject(table.Row{table.ARN(aws.ToString(r.ResourceArn)), timeCell(r.LastModified)}, r))
 	}
 	return rows, output.NextToken, nil
 }
@@ -231,7 +230,7 @@ func (c *LakeFormationClient) GetDatabaseDetails(databaseName string) ([]table.R
 		{"Description", aws.ToString(output.Database.Description)},
 	}
 
-	return rows, nil
+	return withObject(rows, output.Database), nil
 }
 
 func (c *LakeFormationClient) GetDatabaseTags(databaseName string) ([]table.Row, error) {
@@ -249,7 +248,7 @@ func (c *LakeFormationClient) GetDatabaseTags(databaseName string) ([]table.Row,
 	}
 	var rows []table.Row
 	for _, t := range output.LFTagOnDatabase {
-		rows = append(rows, []string{aws.ToString(t.TagKey), strings.Join(t.TagValues, ",")})
+		rows = append(rows, table.Row{aws.ToString(t.TagKey), strings.Join(t.TagValues, ",")})
 	}
 
 	return rows, nil
@@ -270,15 +269,15 @@ func (c *LakeFormationClient) GetTableDetailsAndSchema(tableName string, databas
 		{"Database Name", aws.ToString(output.Table.DatabaseName)},
 		{"Location", aws.ToString(output.Table.StorageDescriptor.Location)},
 		{"Description", aws.ToString(output.Table.Description)},
-		{"Last Updated", formatTime(output.Table.UpdateTime)},
+		{"Last Updated", timeCell(output.Table.UpdateTime)},
 	}
 
 	var schemaRows []table.Row
 	for i, c := range output.Table.StorageDescriptor.Columns {
-		schemaRows = append(schemaRows, []string{strconv.Itoa(i + 1), aws.ToString(c.Name), aws.ToString(c.Type)})
+		schemaRows = append(schemaRows, table.WithObject(table.Row{i + 1, aws.ToString(c.Name), aws.ToString(c.Type)}, c))
 	}
 
-	return detailsRows, schemaRows, nil
+	return withObject(detailsRows, output.Table), schemaRows, nil
 }
 
 func (c *LakeFormationClient) GetTableTags(tableName string, databaseName string) ([]table.Row, error) {
@@ -297,7 +296,7 @@ func (c *LakeFormationClient) GetTableTags(tableName string, databaseName string
 	}
 	var rows []table.Row
 	for _, t := range output.LFTagsOnTable {
-		rows = append(rows, []string{aws.ToString(t.TagKey), strings.Join(t.TagValues, ",")})
+		rows = append(rows, table.Row{aws.ToString(t.TagKey), strings.Join(t.TagValues, ",")})
 	}
 
 	return rows, nil
@@ -39,13 +39,13 @@ func (c *LambdaClient) GetFunctions(nextToken *string) ([]table.Row, *string, er
 	for _, f := range output.Functions {
 		lastModifiedTime, _ := time.Parse(ISO_8601, aws.ToString(f.LastModified))
 
-		rows = append(rows, table.Row{
+		rows = append(rows, table.WithObject(table.Row{
 			aws.ToString(f.FunctionName),
 			string(f.Runtime),
-			formatTime(&lastModifiedTime),
+			lastModifiedTime,
 			aws.ToString(f.Description),
-			aws.ToString(f.FunctionArn),
-		})
+			table.ARN(aws.ToString(f.FunctionArn)),
+		}, f))
 	}
 
 	return rows, output.NextMarker, nil
@@ -70,16 +70,16 @@ func (c *LambdaClient) GetFunctionDetails(functionName string) ([]table.Row, err
 	rows := []table.Row{
 		{"Function name", aws.ToString(cfg.FunctionName)},
 		{"Runtime", aws.ToString(cfg.FunctionName)},
-		{"Memory", fmt.Sprint(aws.ToInt32(cfg.MemorySize))},
-		{"Last modified", formatTime(&lastModifiedTime)},
+		{"Memory", aws.ToInt32(cfg.MemorySize)},
+		{"Last modified", lastModifiedTime},
 		{"Description", aws.ToString(cfg.Description)},
-		{"ARN", aws.ToString(cfg.FunctionArn)},
+		{"ARN", table.ARN(aws.ToString(cfg.FunctionArn))},
 		{"Handler", aws.ToString(cfg.Handler)},
-		{"Role", aws.ToString(cfg.Role)},
+		{"Role", table.ARN(aws.ToString(cfg.Role))},
 		{"VPC Id", vpcId},
 	}
 
-	return rows, nil
+	return withObject(rows, output), nil
 }
 
 func (c *LambdaClient) GetMetric(functionName string, metricName string, statistic types.Statistic) ([]float64, error) {
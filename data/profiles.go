@@ -0,0 +1,100 @@
+package data
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+const DEFAULT_PROFILE = "default"
+
+// Standard (non opt-in) AWS regions
+var REGIONS = []string{
+	"us-east-1",
+	"us-east-2",
+	"us-west-1",
+	"us-west-2",
+	"ca-central-1",
+	"eu-central-1",
+	"eu-west-1",
+	"eu-west-2",
+	"eu-west-3",
+	"eu-north-1",
+	"ap-northeast-1",
+	"ap-northeast-2",
+	"ap-northeast-3",
+	"ap-south-1",
+	"ap-southeast-1",
+	"ap-southeast-2",
+	"sa-east-1",
+}
+
+// Lists the profile names found in the shared config and credentials files
+func ListProfiles() ([]string, error) {
+	configFile := os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" {
+		configFile = config.DefaultSharedConfigFilename()
+	}
+	credentialsFile := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		credentialsFile = config.DefaultSharedCredentialsFilename()
+	}
+
+	profiles := map[string]bool{}
+	for _, path := range []string{configFile, credentialsFile} {
+		// Only the config file prefixes profile sections with "profile "
+		names, err := readProfileSections(path, path == configFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range names {
+			profiles[n] = true
+		}
+	}
+
+	var names []string
+	for n := range profiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func readProfileSections(path string, prefixed bool) ([]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening AWS config file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+			continue
+		}
+		section := strings.TrimSpace(line[1 : len(line)-1])
+		if prefixed && section != DEFAULT_PROFILE {
+			if !strings.HasPrefix(section, "profile ") {
+				// e.g. [sso-session ...]
+				continue
+			}
+			section = strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+		}
+		names = append(names, section)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading AWS config file %s: %w", path, err)
+	}
+	return names, nil
+}
@@ -37,15 +37,15 @@ func (c *RDSClient) GetDBInstances(nextToken *string) ([]table.Row, *string, err
 
 	var rows []table.Row
 	for _, i := range output.DBInstances {
-		rows = append(rows, table.Row{
+		rows = append(rows, table.WithObject(table.Row{
 			aws.ToString(i.DBInstanceIdentifier),
 			aws.ToString(i.Engine),
 			aws.ToString(i.AvailabilityZone),
 			aws.ToString(i.DBInstanceClass),
 			aws.ToString(i.DBInstanceStatus),
 			aws.ToString(i.DBSubnetGroup.VpcId),
-			formatBool(i.MultiAZ),
-		})
+			i.MultiAZ,
+		}, i))
 	}
 	return rows, output.Marker, nil
 }
@@ -67,15 +67,15 @@ func (c *RDSClient) GetInstanceDetails(instance string) ([]table.Row, error) {
 		{"Size", aws.ToString(i.DBInstanceClass)},
 		{"Status", aws.ToString(i.DBInstanceStatus)},
 		{"Endpoint", aws.ToString(i.Endpoint.Address)},
-		{"Port", fmt.Sprintf("%d", i.Endpoint.Port)},
+		{"Port", i.Endpoint.Port},
 		{"VPC", aws.ToString(i.DBSubnetGroup.VpcId)},
-		{"Multi-AZ", formatBool(i.MultiAZ)},
-		{"ARN", aws.ToString(i.DBInstanceArn)},
-		{"Created on", formatTime(i.InstanceCreateTime)},
-		{"Storage", fmt.Sprintf("%d", i.AllocatedStorage)},
+		{"Multi-AZ", i.MultiAZ},
+		{"ARN", table.ARN(aws.ToString(i.DBInstanceArn))},
+		{"Created on", timeCell(i.InstanceCreateTime)},
+		{"Storage", i.AllocatedStorage},
 		{"Storage type", aws.ToString(i.StorageType)},
 	}
-	return rows, nil
+	return withObject(rows, i), nil
 }
 
 func (c *RDSClient) GetInstanceTags(instance string) ([]table.Row, error) {
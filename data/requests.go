@@ -0,0 +1,150 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Identifies an API request by its operation and parameters, so that its response can be stored
+// and served again later by the cache or a recording
+type requestKey struct {
+	Service   string
+	Operation string
+	Region    string
+	Hash      string
+}
+
+type requestKeyKey struct{}
+
+// Returned when a response should come from storage but there isn't one for the request
+type missError string
+
+func (e missError) Error() string {
+	return string(e)
+}
+
+// Stops the SDK treating a miss like a broken connection and retrying it
+func (missError) RetryableError() bool {
+	return false
+}
+
+// Keys every request made by clients built from cfg. Only needs adding once per config.
+func addRequestKeyMiddleware(cfg *aws.Config) {
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(
+			middleware.InitializeMiddlewareFunc("SawsyRequestKey", keyRequest),
+			middleware.After,
+		)
+	})
+}
+
+func keyRequest(
+	ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+) (middleware.InitializeOutput, middleware.Metadata, error) {
+	params, err := normalizeParams(in.Parameters)
+	if err != nil {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	key := requestKey{
+		Service:   awsmiddleware.GetServiceID(ctx),
+		Operation: awsmiddleware.GetOperationName(ctx),
+		Region:    awsmiddleware.GetRegion(ctx),
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n", key.Region, key.Service, key.Operation)
+	h.Write(params)
+	key.Hash = hex.EncodeToString(h.Sum(nil))
+
+	ctx = middleware.WithStackValue(ctx, requestKeyKey{}, key)
+	return next.HandleInitialize(ctx, in)
+}
+
+// The key stored by the middleware. The stack values are still in the context of the HTTP
+// request, so this works from an HTTP client too.
+func getRequestKey(ctx context.Context) (requestKey, bool) {
+	key, ok := middleware.GetStackValue(ctx, requestKeyKey{}).(requestKey)
+	return key, ok
+}
+
+// Serializes the parameters with any timestamps blanked out, as they're usually relative to now
+// (e.g. a metric's time range) and would otherwise mean no two requests ever match
+func normalizeParams(params interface{}) ([]byte, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(blankTimestamps(v))
+}
+
+func blankTimestamps(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = blankTimestamps(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = blankTimestamps(e)
+		}
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return ""
+		}
+	}
+	return v
+}
+
+// A raw HTTP response, as kept on disk
+type storedResponse struct {
+	StoredAt   time.Time
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Reads the body of resp so it can be stored, leaving resp readable for the SDK
+func newStoredResponse(resp *http.Response) (storedResponse, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return storedResponse{}, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return storedResponse{
+		StoredAt:   time.Now(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}, nil
+}
+
+func (r storedResponse) toHTTP(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(r.StatusCode),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header,
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
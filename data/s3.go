@@ -3,7 +3,6 @@ package data
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -34,11 +33,11 @@ func (c *S3Client) GetBuckets() ([]table.Row, error) {
 
 	var rows []table.Row
 	for _, b := range output.Buckets {
-		rows = append(rows, table.Row{
+		rows = append(rows, table.WithObject(table.Row{
 			aws.ToString(b.Name),
 			LOADING_ALIAS,
-			formatTime(b.CreationDate),
-		})
+			timeCell(b.CreationDate),
+		}, b))
 	}
 
 	return rows, nil
@@ -52,7 +51,20 @@ func (c *S3Client) GetBucketRegion(bucket string) (string, error) {
 	return region, nil
 }
 
+// Requests about a bucket have to be made in its region. Looks it up if it isn't known already,
+// e.g. for a bucket that was opened by a link rather than from the list of buckets.
+func (c *S3Client) resolveRegion(bucket string, region string) (string, error) {
+	if region != "" {
+		return region, nil
+	}
+	return c.GetBucketRegion(bucket)
+}
+
 func (c *S3Client) GetBucketPolicy(bucket string, region string) (string, error) {
+	region, err := c.resolveRegion(bucket, region)
+	if err != nil {
+		return "", err
+	}
 	input := s3.GetBucketPolicyInput{
 		Bucket: aws.String(bucket),
 	}
@@ -65,6 +77,10 @@ func (c *S3Client) GetBucketPolicy(bucket string, region string) (string, error)
 }
 
 func (c *S3Client) GetBucketTags(bucket string, region string) ([]table.Row, error) {
+	region, err := c.resolveRegion(bucket, region)
+	if err != nil {
+		return nil, err
+	}
 	input := s3.GetBucketTaggingInput{
 		Bucket: aws.String(bucket),
 	}
@@ -82,14 +98,16 @@ func (c *S3Client) GetBucketTags(bucket string, region string) ([]table.Row, err
 }
 
 func (c *S3Client) GetObjects(bucket string, region string, prefix string, nextToken *string) ([]table.Row, *string, error) {
+	region, err := c.resolveRegion(bucket, region)
+	if err != nil {
+		return nil, nil, err
+	}
 	input := s3.ListObjectsV2Input{
-		Bucket:    aws.String(bucket),
-		Delimiter: aws.String("/"),
-		Prefix:    aws.String(prefix),
+		Bucket:            aws.String(bucket),
+		Delimiter:         aws.String("/"),
+		Prefix:            aws.String(prefix),
+		ContinuationToken: nextToken,
 	}
-	// if nextToken != nil {
-	//     input.ContinuationToken = nextToken
-	// }
 
 	output, err := c.s3.ListObjectsV2(c.ctx, &input, func(options *s3.Options) { options.Region = region })
 	if err != nil {
@@ -99,32 +117,34 @@ func (c *S3Client) GetObjects(bucket string, region string, prefix string, nextT
 	var rows []table.Row
 
 	for _, o := range output.CommonPrefixes {
-		rows = append(rows, table.Row{
+		rows = append(rows, table.WithObject(table.Row{
 			fmt.Sprintf("%s %s", icons.FOLDER, strings.Replace(aws.ToString(o.Prefix), prefix, "", 1)),
 			"-",
 			"-",
-		})
+		}, o))
 	}
 
 	for _, o := range output.Contents {
-		rows = append(rows, table.Row{
+		rows = append(rows, table.WithObject(table.Row{
 			fmt.Sprintf("%s %s", icons.FILE, strings.Replace(aws.ToString(o.Key), prefix, "", 1)),
-			formatTime(o.LastModified),
-			strconv.FormatInt(o.Size, 10),
-		})
+			timeCell(o.LastModified),
+			table.Bytes(o.Size),
+		}, o))
 	}
 
-	// Don't return a next token, for large buckets it'll just kill the app with too much data
-	// Will need to send the search query to the server instead
-	return rows, nil, nil
+	return rows, output.NextContinuationToken, nil
 }
 
 func (c *S3Client) GetObjectProperties(bucket string, key string, region string) ([]table.Row, error) {
+	region, err := c.resolveRegion(bucket, region)
+	if err != nil {
+		return nil, err
+	}
 	rows := []table.Row{
 		{"Bucket", bucket},
 		{"Key", key},
 		{"Region", region},
-		{"ARN", fmt.Sprintf("arn:aws:s3:::%s/%s", bucket, key)},
+		{"ARN", table.ARN(fmt.Sprintf("arn:aws:s3:::%s/%s", bucket, key))},
 	}
 
 	headInput := s3.HeadObjectInput{
@@ -136,7 +156,8 @@ func (c *S3Client) GetObjectProperties(bucket string, key string, region string)
 		return nil, fmt.Errorf("error getting properties for object s3://%s/%s: %w", bucket, key, err)
 	}
 
-	rows = append(rows, table.Row{"Last Modified", formatTime(headOutput.LastModified)})
+	rows = append(rows, table.Row{"Last Modified", timeCell(headOutput.LastModified)})
+	rows = append(rows, table.Row{"Size", table.Bytes(headOutput.ContentLength)})
 	rows = append(rows, table.Row{"ETag", aws.ToString(headOutput.ETag)})
 
 	aclInput := s3.GetObjectAclInput{
@@ -150,5 +171,5 @@ func (c *S3Client) GetObjectProperties(bucket string, key string, region string)
 
 	rows = append(rows, table.Row{"Owner", aws.ToString(aclOutput.Owner.DisplayName)})
 
-	return rows, nil
+	return withObject(rows, headOutput), nil
 }
@@ -3,10 +3,10 @@ package data
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/danielcmessias/sawsy/ui/components/table"
 )
 
 const LOADING_ALIAS = "..."
@@ -27,23 +27,24 @@ func statisticOfDatapoint(datapoint types.Datapoint, statistic types.Statistic)
 	return nil
 }
 
-func formatBool(b bool) string {
-	if b {
-		return "Yes"
-	} else {
-		return "No"
+// The zero time, shown blank, if t is nil
+func timeCell(t *time.Time) table.Cell {
+	if t == nil {
+		return time.Time{}
 	}
+	return *t
 }
 
-func formatTime(t *time.Time) string {
-	return t.Format("02/01/2006 15:04:05")
+func secondsCell(seconds float64) table.Cell {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
 }
 
-func formatSeconds(seconds int) string {
-	m := seconds / 60
-	s := seconds % 60
-	str := fmt.Sprintf("%dm %ds", m, s)
-	return str
+// Keeps the object a page of details was made from on each of its rows
+func withObject(rows []table.Row, object interface{}) []table.Row {
+	for i, row := range rows {
+		rows[i] = table.WithObject(row, object)
+	}
+	return rows
 }
 
 func formatJson(jsonString string) string {
@@ -1,24 +1,105 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/cli"
 	"github.com/danielcmessias/sawsy/config"
+	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui"
+	"github.com/danielcmessias/sawsy/ui/links"
 )
 
 func main() {
-	firstPage := "services"
-	args := os.Args[1:]
-	if len(args) > 0 {
-		firstPage = args[0]
+	noCache := flag.Bool("no-cache", false, "don't read or write the response cache")
+	record := flag.String("record", "", "record every AWS response to `file`")
+	replay := flag.String("replay", "", "serve AWS responses from a `file` made with --record, instead of calling AWS")
+	endpointUrl := flag.String("endpoint-url", "", "send requests for every service to `url`, e.g. LocalStack")
+	endpoints := endpointsFlag{}
+	flag.Var(endpoints, "endpoint", "send requests for a service to a url, as `service=url` (repeatable)")
+	s3PathStyle := flag.Bool("s3-path-style", false, "use path-style addressing for S3 buckets")
+	fresh := flag.Bool("fresh", false, "start from the services page, ignoring where the last session left off")
+	skipSts := flag.Bool("skip-sts", false, "don't look up the account id with STS")
+	flag.Parse()
+
+	args := flag.Args()
+
+	config, err := config.ReadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *noCache {
+		config.Cache.Enabled = false
+	}
+	if *endpointUrl != "" {
+		endpoints["default"] = *endpointUrl
+	}
+	if len(endpoints) > 0 {
+		if config.AWS.Endpoints == nil {
+			config.AWS.Endpoints = map[string]string{}
+		}
+		for service, url := range endpoints {
+			config.AWS.Endpoints[service] = url
+		}
+	}
+	if *s3PathStyle {
+		config.AWS.S3PathStyle = true
+	}
+	if *skipSts {
+		config.AWS.SkipSTS = true
 	}
 
-	config, _ := config.ReadConfig()
+	clientOptions := data.ClientOptions{
+		Cache: data.CacheOptions{
+			Enabled: config.Cache.Enabled,
+			TTLs:    config.Cache.TTL,
+		},
+		Endpoints:   config.AWS.Endpoints,
+		S3PathStyle: config.AWS.S3PathStyle,
+		SkipSTS:     config.AWS.SkipSTS,
+		AccountId:   config.AWS.AccountId,
+	}
+	if *record != "" {
+		recorder, err := data.NewRecorder(*record)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer recorder.Close()
+		clientOptions.Recorder = recorder
+	}
+	if *replay != "" {
+		replay, err := data.LoadReplay(*replay)
+		if err != nil {
+			log.Fatal(err)
+		}
+		clientOptions.Replay = replay
+		// Keep recorded responses out of the real cache
+		clientOptions.Cache.Enabled = false
+	}
 
-	m, err := ui.NewModel(config, firstPage)
+	if len(args) > 0 && cli.IsCommand(args[0]) {
+		if err := cli.Run(args, config, clientOptions, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "sawsy: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// No page means wherever the last session left off
+	firstPage := links.Link{}
+	if len(args) > 0 {
+		firstPage, err = links.Parse(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	m, err := ui.NewModel(config, clientOptions, firstPage, *fresh)
 	if err != nil {
 		log.Fatalf("Error creating UI model: %v", err)
 	}
@@ -30,3 +111,23 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// Collects repeated --endpoint service=url flags
+type endpointsFlag map[string]string
+
+func (f endpointsFlag) String() string {
+	var endpoints []string
+	for service, url := range f {
+		endpoints = append(endpoints, service+"="+url)
+	}
+	return strings.Join(endpoints, ",")
+}
+
+func (f endpointsFlag) Set(value string) error {
+	service, url, ok := strings.Cut(value, "=")
+	if !ok || service == "" || url == "" {
+		return fmt.Errorf("expected service=url, got %q", value)
+	}
+	f[strings.ToLower(service)] = url
+	return nil
+}
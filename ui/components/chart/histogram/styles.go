@@ -6,9 +6,16 @@ import (
 )
 
 var (
-	barsStyle = lipgloss.NewStyle().
+	barsStyle lipgloss.Style
+	axisStyle lipgloss.Style
+)
+
+func init() {
+	styles.OnThemeChange(func() {
+		barsStyle = lipgloss.NewStyle().
 			Foreground(styles.Theme.HighlightRow)
 
-	axisStyle = lipgloss.NewStyle().
+		axisStyle = lipgloss.NewStyle().
 			Foreground(styles.Theme.MainText)
-)
+	})
+}
@@ -8,28 +8,52 @@ import (
 	"github.com/alecthomas/chroma"
 	"github.com/alecthomas/chroma/lexers"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/glamour"
 	gansi "github.com/charmbracelet/glamour/ansi"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/danielcmessias/sawsy/ui/components/pane"
+	"github.com/danielcmessias/sawsy/ui/components/toast"
 	"github.com/danielcmessias/sawsy/ui/context"
+	"github.com/danielcmessias/sawsy/ui/styles"
+	"github.com/danielcmessias/sawsy/utils/clipboard"
 	"github.com/muesli/termenv"
 )
 
 var (
-	lineDigitStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("239"))
-	lineBarStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("236"))
+	lineDigitStyle  lipgloss.Style
+	lineBarStyle    lipgloss.Style
+	matchDigitStyle lipgloss.Style
+	promptStyle     lipgloss.Style
+	matchCountStyle lipgloss.Style
 )
 
+func init() {
+	styles.OnThemeChange(func() {
+		lineDigitStyle = lipgloss.NewStyle().Foreground(styles.Theme.FaintText)
+		lineBarStyle = lipgloss.NewStyle().Foreground(styles.Theme.FaintBorder)
+		matchDigitStyle = lipgloss.NewStyle().Foreground(styles.Theme.SearchPrompt).Bold(true)
+		promptStyle = lipgloss.NewStyle().Foreground(styles.Theme.SearchPrompt)
+		matchCountStyle = lipgloss.NewStyle().Foreground(styles.Theme.FaintText).PaddingLeft(2)
+	})
+}
+
+const searchHeight = 1
+
 type Model struct {
 	ctx            *context.ProgramContext
 	viewport       viewport.Model
-	renderContext  gansi.RenderContext
 	showLineNumber bool
 	rawContent     string
 	filepath       string
+	width          int
+	height         int
+
+	search      textinput.Model
+	matches     []int // Lines of rawContent matching the search
+	currMatch   int
+	lineOffsets []int // Where each line of rawContent starts in the viewport, as long lines wrap
 
 	pane.Pane
 }
@@ -54,20 +78,29 @@ func (s CodeSpec) NewFromSpec(ctx *context.ProgramContext, spec pane.PaneSpec) p
 }
 
 func New(ctx *context.ProgramContext, spec CodeSpec) *Model {
+	search := textinput.New()
+	search.Prompt = "Search: "
+	search.PromptStyle = promptStyle
+	search.Width = 40
+
 	return &Model{
 		Pane: pane.New(spec.BaseSpec),
 
-		ctx:      ctx,
-		viewport: viewport.Model{},
-		renderContext: gansi.NewRenderContext(gansi.Options{
-			ColorProfile: termenv.TrueColor,
-			Styles:       glamour.DraculaStyleConfig,
-		}),
+		ctx:            ctx,
+		viewport:       viewport.Model{},
 		showLineNumber: true,
+		search:         search,
 	}
 }
 
 func (m *Model) Update(msg tea.Msg) (pane.Pane, tea.Cmd, bool) {
+	if m.search.Focused() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			// Enter would go on to inspect, on the way out of the search
+			return m, m.updateSearch(msg), key.Matches(msg, m.ctx.Keys.EndSearch)
+		}
+	}
+
 	var cmds []tea.Cmd
 	vp, cmd := m.viewport.Update(msg)
 	m.viewport = vp
@@ -81,25 +114,140 @@ func (m *Model) Update(msg tea.Msg) (pane.Pane, tea.Cmd, bool) {
 		case key.Matches(msg, m.ctx.Keys.Up):
 			m.viewport.LineUp(1)
 			return m, nil, false
+		case key.Matches(msg, m.ctx.Keys.StartSearch) && !m.ctx.LockKeyboardCapture:
+			m.ctx.LockKeyboardCapture = true
+			m.resize()
+			return m, m.search.Focus(), false
+		case key.Matches(msg, m.ctx.Keys.CopyCell) && !m.ctx.LockKeyboardCapture:
+			return m, m.copyContent(), false
+		case key.Matches(msg, m.ctx.Keys.NextMatch) && !m.ctx.LockKeyboardCapture:
+			m.jumpToMatch(m.currMatch + 1)
+		case key.Matches(msg, m.ctx.Keys.PrevMatch) && !m.ctx.LockKeyboardCapture:
+			m.jumpToMatch(m.currMatch - 1)
 		}
 	}
 	return m, tea.Batch(cmds...), false
 }
 
 func (m *Model) View() string {
-	return m.viewport.View()
+	if !m.searchShown() {
+		return m.viewport.View()
+	}
+	search := m.search.View()
+	if m.search.Value() != "" {
+		count := "no matches"
+		if len(m.matches) > 0 {
+			count = fmt.Sprintf("%d/%d", m.currMatch+1, len(m.matches))
+		}
+		search = lipgloss.JoinHorizontal(lipgloss.Top, search, matchCountStyle.Render(count))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, m.viewport.View(), search)
 }
 
 func (m *Model) SetSize(width int, height int) {
-	m.viewport.Width = width
-	m.viewport.Height = height
+	m.width = width
+	m.height = height
+	m.resize()
+}
+
+// Makes room for the search, or takes it back
+func (m *Model) resize() {
+	m.viewport.Width = m.width
+	m.viewport.Height = m.height
+	if m.searchShown() {
+		m.viewport.Height -= searchHeight
+	}
 	m.SetContent(m.rawContent, m.filepath)
 }
 
-func (m *Model) Hide() {}
+func (m *Model) Hide() {
+	if m.search.Focused() {
+		m.search.Blur()
+		m.ctx.LockKeyboardCapture = false
+	}
+}
+
+// The search stays below the code after it's entered, for moving between the matches
+func (m *Model) searchShown() bool {
+	return m.search.Focused() || m.search.Value() != ""
+}
+
+func (m *Model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	if key.Matches(msg, m.ctx.Keys.EndSearch) {
+		m.search.Blur()
+		m.ctx.LockKeyboardCapture = false
+		m.resize()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	m.findMatches()
+	// Start from the first match from where the code is scrolled to
+	first := 0
+	for i, line := range m.matches {
+		if m.lineOffset(line) >= m.viewport.YOffset {
+			first = i
+			break
+		}
+	}
+	m.SetContent(m.rawContent, m.filepath)
+	m.jumpToMatch(first)
+	return cmd
+}
+
+// The lines containing the search, ignoring case
+func (m *Model) findMatches() {
+	m.matches = nil
+	m.currMatch = 0
+	needle := strings.ToLower(m.search.Value())
+	if needle == "" {
+		return
+	}
+	for i, line := range strings.Split(m.rawContent, "\n") {
+		if strings.Contains(strings.ToLower(line), needle) {
+			m.matches = append(m.matches, i)
+		}
+	}
+}
+
+// Scrolls to the ith match, wrapping around at either end
+func (m *Model) jumpToMatch(i int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.currMatch = (i + len(m.matches)) % len(m.matches)
+	m.viewport.SetYOffset(m.lineOffset(m.matches[m.currMatch]))
+}
+
+func (m *Model) lineOffset(line int) int {
+	if line < len(m.lineOffsets) {
+		return m.lineOffsets[line]
+	}
+	return line
+}
+
+// Copies the whole content, as it was set
+func (m *Model) copyContent() tea.Cmd {
+	content := m.rawContent
+	if content == "" {
+		return nil
+	}
+	return clipboard.Copy(content, toast.ShowMsg{
+		Message: fmt.Sprintf("Copied %d lines", strings.Count(content, "\n")+1),
+	})
+}
+
+// The whole content, as it was set
+func (m *Model) Content() string {
+	return m.rawContent
+}
 
 func (m *Model) SetContent(content string, filepath string) {
-	m.rawContent = content
+	if content != m.rawContent {
+		m.rawContent = content
+		m.findMatches()
+	}
 	m.filepath = filepath
 	// Generiously 'borrowed' from soft-serve
 	// https://github.com/charmbracelet/soft-serve/blob/main/ui/components/code/code.go#L185
@@ -122,16 +270,15 @@ func (m *Model) SetContent(content string, filepath string) {
 		Language: lexer.Config().Name,
 	}
 	s := strings.Builder{}
-	rc := m.renderContext
+	st, _ := styles.CodeStyleConfig(styles.Theme.CodeStyle)
 	if m.showLineNumber {
-		st := glamour.DraculaStyleConfig
 		var m uint
 		st.CodeBlock.Margin = &m
-		rc = gansi.NewRenderContext(gansi.Options{
-			ColorProfile: termenv.TrueColor,
-			Styles:       st,
-		})
 	}
+	rc := gansi.NewRenderContext(gansi.Options{
+		ColorProfile: termenv.TrueColor,
+		Styles:       st,
+	})
 	err := formatter.Render(&s, rc)
 	if err != nil {
 		log.Fatal(err)
@@ -139,14 +286,29 @@ func (m *Model) SetContent(content string, filepath string) {
 	c := s.String()
 	if m.showLineNumber {
 		var ml int
-		c, ml = withLineNumber(c)
+		matched := make(map[int]bool, len(m.matches))
+		for _, line := range m.matches {
+			matched[line] = true
+		}
+		c, ml = withLineNumber(c, matched)
 		width -= ml
 	}
 
-	m.viewport.SetContent(lipgloss.NewStyle().Width(width).Render(c))
+	// Each line is wrapped on its own, to know where it ends up
+	lines := strings.Split(c, "\n")
+	m.lineOffsets = make([]int, len(lines))
+	offset := 0
+	style := lipgloss.NewStyle().Width(width)
+	for i, line := range lines {
+		lines[i] = style.Render(line)
+		m.lineOffsets[i] = offset
+		offset += lipgloss.Height(lines[i])
+	}
+	m.viewport.SetContent(strings.Join(lines, "\n"))
 }
 
-func withLineNumber(s string) (string, int) {
+// Numbers the lines, with the ones in matched standing out
+func withLineNumber(s string, matched map[int]bool) (string, int) {
 	lines := strings.Split(s, "\n")
 	// NB: len() is not a particularly safe way to count string width (because
 	// it's counting bytes instead of runes) but in this case it's okay
@@ -155,7 +317,11 @@ func withLineNumber(s string) (string, int) {
 	for i, l := range lines {
 		digit := fmt.Sprintf("%*d", mll, i+1)
 		bar := "│"
-		digit = lineDigitStyle.Render(digit)
+		if matched[i] {
+			digit = matchDigitStyle.Render(digit)
+		} else {
+			digit = lineDigitStyle.Render(digit)
+		}
 		bar = lineBarStyle.Render(bar)
 		if i < len(lines)-1 || len(l) != 0 {
 			// If the final line was a newline we'll get an empty string for
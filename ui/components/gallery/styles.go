@@ -6,25 +6,37 @@ import (
 )
 
 var (
-	titleStyle = lipgloss.NewStyle().
+	titleStyle         lipgloss.Style
+	selectedTitleStyle lipgloss.Style
+	itemStyle          lipgloss.Style
+	selectedItemStyle  lipgloss.Style
+
+	paginatorStyle = lipgloss.NewStyle().
+			Align(lipgloss.Center)
+	activeDot   string
+	inactiveDot string
+)
+
+func init() {
+	styles.OnThemeChange(func() {
+		titleStyle = lipgloss.NewStyle().
 			Foreground(styles.Theme.MainText).
 			Align(lipgloss.Center)
 
-	selectedTitleStyle = titleStyle.Copy().
-				Foreground(styles.Theme.HighlightColumn).
-				Bold(true)
+		selectedTitleStyle = titleStyle.Copy().
+			Foreground(styles.Theme.HighlightColumn).
+			Bold(true)
 
-	itemStyle = lipgloss.NewStyle().
+		itemStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(styles.Theme.Border).
 			BorderTitleStyle(titleStyle)
 
-	selectedItemStyle = itemStyle.Copy().
-				BorderForeground(styles.Theme.HighlightColumn).
-				BorderTitleStyle(selectedTitleStyle)
+		selectedItemStyle = itemStyle.Copy().
+			BorderForeground(styles.Theme.HighlightColumn).
+			BorderTitleStyle(selectedTitleStyle)
 
-	paginatorStyle = lipgloss.NewStyle().
-			Align(lipgloss.Center)
-	activeDot   = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "235", Dark: "252"}).PaddingLeft(1).PaddingRight(1).Render("⬤")
-	inactiveDot = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "250", Dark: "238"}).PaddingLeft(1).PaddingRight(1).Render("⬤")
-)
+		activeDot = lipgloss.NewStyle().Foreground(styles.Theme.MainText).PaddingLeft(1).PaddingRight(1).Render("⬤")
+		inactiveDot = lipgloss.NewStyle().Foreground(styles.Theme.Border).PaddingLeft(1).PaddingRight(1).Render("⬤")
+	})
+}
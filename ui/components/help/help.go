@@ -6,7 +6,6 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/ui/context"
 	"github.com/danielcmessias/sawsy/ui/styles"
-	"github.com/danielcmessias/sawsy/utils"
 )
 
 type Model struct {
@@ -36,7 +35,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, utils.Keys.Help):
+		case key.Matches(msg, m.ctx.Keys.Help):
 			m.help.ShowAll = !m.help.ShowAll
 		}
 	}
@@ -47,7 +46,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 func (m Model) View() string {
 	return styles.FooterStyle.Copy().
 		Width(m.ctx.ScreenWidth).
-		Render(m.help.View(utils.Keys))
+		Render(m.help.View(m.ctx.Keys))
 }
 
 func (m *Model) SetWidth(width int) {
@@ -8,5 +8,11 @@ import (
 var (
 	HelpHeight = 3
 
-	helpTextStyle = lipgloss.NewStyle().Foreground(styles.Theme.FaintText)
+	helpTextStyle lipgloss.Style
 )
+
+func init() {
+	styles.OnThemeChange(func() {
+		helpTextStyle = lipgloss.NewStyle().Foreground(styles.Theme.FaintText)
+	})
+}
@@ -3,49 +3,57 @@ package listviewport
 import (
 	"fmt"
 
-	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/danielcmessias/sawsy/utils"
 )
 
+// A window onto a list of items, keeping track of the selected one and which are visible. Lists
+// can be far too long to render in full, so only the visible items are rendered, by whoever owns
+// the list, and handed over with SyncViewPort.
 type Model struct {
-	viewport       viewport.Model
-	TopBoundId     int
-	BottomBoundId  int
+	width          int
+	height         int // Of the items, less the pager
+	offset         int // The first visible item
 	currId         int
+	content        string
 	ListItemHeight int
 	NumItems       int
 	ItemTypeLabel  string
+	Footnote       string // Shown after the item count
+	Columns        string // Which columns are in view, shown after the footnote
 }
 
 func NewModel(itemTypeLabel string, numItems, listItemHeight int) Model {
-	model := Model{
+	return Model{
 		NumItems:       numItems,
 		ListItemHeight: listItemHeight,
-		currId:         0,
-		viewport:       viewport.Model{},
-		TopBoundId:     1,
 		ItemTypeLabel:  itemTypeLabel,
 	}
-	model.BottomBoundId = utils.Min(model.NumItems-1, model.GetNumRowsPerPage()-1)
-	return model
 }
 
 func (m *Model) SetNumItems(numItems int) {
 	m.NumItems = numItems
-	m.BottomBoundId = utils.Min(m.NumItems-1, m.GetNumRowsPerPage()-1)
+	m.currId = utils.Max(utils.Min(m.currId, numItems-1), 0)
+	m.scrollToCurrItem()
 }
 
+// Sets the rendered visible items, see VisibleRange
 func (m *Model) SyncViewPort(content string) {
-	m.viewport.SetContent(content)
+	m.content = content
 }
 
 func (m *Model) GetNumRowsPerPage() int {
-	return (m.viewport.Height / m.ListItemHeight)
+	return m.height / m.ListItemHeight
+}
+
+// The first visible item and the one after the last
+func (m *Model) VisibleRange() (int, int) {
+	return m.offset, utils.Min(m.offset+m.GetNumRowsPerPage(), m.NumItems)
 }
 
 func (m *Model) ResetCurrItem() {
 	m.currId = 0
+	m.scrollToCurrItem()
 }
 
 func (m *Model) GetCurrItem() int {
@@ -53,47 +61,45 @@ func (m *Model) GetCurrItem() int {
 }
 
 func (m *Model) NextItem() int {
-	atBottomOfViewport := m.currId >= m.BottomBoundId
-	if atBottomOfViewport && !m.viewport.AtBottom() {
-		m.TopBoundId += 1
-		m.BottomBoundId += 1
-		m.viewport.LineDown(m.ListItemHeight)
-	}
-
-	newId := utils.Min(m.currId+1, m.NumItems-1)
-	newId = utils.Max(newId, 0)
-	m.currId = newId
-
+	m.currId = utils.Max(utils.Min(m.currId+1, m.NumItems-1), 0)
+	m.scrollToCurrItem()
 	return m.currId
 }
 
 func (m *Model) PrevItem() int {
-	atTopOfViewport := m.currId <= m.TopBoundId
-	if atTopOfViewport && !m.viewport.AtTop() {
-		m.TopBoundId -= 1
-		m.BottomBoundId -= 1
-		m.viewport.LineUp(m.ListItemHeight)
-	}
-
 	m.currId = utils.Max(m.currId-1, 0)
+	m.scrollToCurrItem()
 	return m.currId
 }
 
 func (m *Model) FirstItem() int {
 	m.currId = 0
-	m.viewport.GotoTop()
+	m.scrollToCurrItem()
 	return m.currId
 }
 
 func (m *Model) LastItem() int {
-	m.currId = m.NumItems - 1
-	m.viewport.GotoBottom()
+	m.currId = utils.Max(m.NumItems-1, 0)
+	m.scrollToCurrItem()
 	return m.currId
 }
 
+// Scrolls as little as possible to bring the selected item into view
+func (m *Model) scrollToCurrItem() {
+	rows := utils.Max(m.GetNumRowsPerPage(), 1)
+	if m.currId < m.offset {
+		m.offset = m.currId
+	}
+	if m.currId >= m.offset+rows {
+		m.offset = m.currId - rows + 1
+	}
+	m.offset = utils.Max(utils.Min(m.offset, m.NumItems-rows), 0)
+}
+
 func (m *Model) SetSize(width int, height int) {
-	m.viewport.Width = width
-	m.viewport.Height = height - pagerHeight
+	m.width = width
+	m.height = height - pagerHeight
+	m.scrollToCurrItem()
 }
 
 func (m *Model) View() string {
@@ -105,17 +111,26 @@ func (m *Model) View() string {
 			m.currId+1,
 			m.NumItems,
 		)
+		if m.Footnote != "" {
+			pagerContent = fmt.Sprintf("%s · %s", pagerContent, m.Footnote)
+		}
+		if m.Columns != "" {
+			pagerContent = fmt.Sprintf("%s · %s", pagerContent, m.Columns)
+		}
 	}
-	viewport := m.viewport.View()
+	items := lipgloss.NewStyle().
+		Height(utils.Max(m.height, 0)).
+		MaxHeight(utils.Max(m.height, 0)).
+		Render(m.content)
 
 	pager := pagerStyle.Copy().Render(pagerContent)
 
 	return lipgloss.NewStyle().
-		Width(m.viewport.Width).
-		MaxWidth(m.viewport.Width).
+		Width(m.width).
+		MaxWidth(m.width).
 		Render(lipgloss.JoinVertical(
 			lipgloss.Left,
-			viewport,
+			items,
 			pager,
 		))
 }
@@ -8,10 +8,16 @@ import (
 var (
 	pagerHeight = 2
 
-	pagerStyle = lipgloss.NewStyle().
+	pagerStyle lipgloss.Style
+)
+
+func init() {
+	styles.OnThemeChange(func() {
+		pagerStyle = lipgloss.NewStyle().
 			Height(pagerHeight).
 			MaxHeight(pagerHeight).
 			PaddingTop(1).
 			Bold(true).
 			Foreground(styles.Theme.FaintText)
-)
+	})
+}
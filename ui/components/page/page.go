@@ -1,6 +1,7 @@
 package page
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -22,9 +23,18 @@ type NewRowsMsg struct {
 	PaneId    int
 	Rows      []table.Row
 	NextCmd   tea.Cmd
+	NextToken *string // Set if there are more rows, passed back to FetchNextPage when needed
 	Overwrite bool
 }
 
+// Sent instead of NewRowsMsg (or NewCodeContentMsg) when fetching the data for a pane fails
+type FetchErrorMsg struct {
+	Page     string
+	PaneId   int
+	Err      error
+	RetryCmd tea.Cmd // Re-runs the failed fetch, triggered by the refresh key
+}
+
 type BatchedNewRowsMsg struct {
 	Msgs []NewRowsMsg
 }
@@ -56,23 +66,32 @@ type Model struct {
 	Context interface{}
 	Tabs    tabs.Model
 	Panes   []pane.Pane
+	Errors  map[int]FetchErrorMsg
+
+	width  int
+	height int
 }
 
 type Page interface {
 	Init() tea.Cmd
 	View() string
+	CurrentPaneView() string
 	NextTab() int
 	PrevTab() int
 	FetchData(client *data.Client) tea.Cmd
+	FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd
 	ClearData()
 	AppendRows(tabId int, rows []table.Row)
 	ClearRows(tabId int)
 	GetPageContext() interface{}
 	SetPageContext(context interface{})
 	GetSpec() PageSpec
+	SetError(msg FetchErrorMsg)
+	RetryCurrentPane() tea.Cmd
 
 	GetPaneAt(index int) pane.Pane
 	GetCurrentPaneId() int
+	SetCurrentPaneId(id int)
 
 	Inspect(client *data.Client) tea.Cmd
 
@@ -82,6 +101,27 @@ type Page interface {
 	SetSize(width int, height int)
 }
 
+// Implemented by pages with tables listing resources that have a page of their own, to say which
+// page (and context) the selected row opens. Lets the row be previewed without leaving the list.
+type Previewable interface {
+	InspectTarget() (pageName string, context interface{}, ok bool)
+}
+
+// Changes to the page the selected row of p opens, if it opens one
+func InspectSelected(p Previewable) tea.Cmd {
+	pageName, context, ok := p.InspectTarget()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		return ChangePageMsg{
+			NewPage:     pageName,
+			FetchData:   true,
+			PageContext: context,
+		}
+	}
+}
+
 func New(ctx *context.ProgramContext, spec PageSpec) Model {
 	var tabsList []tabs.Tab
 	var panes []pane.Pane
@@ -90,13 +130,18 @@ func New(ctx *context.ProgramContext, spec PageSpec) Model {
 			Name: s.GetName(),
 			Icon: s.GetIcon(),
 		})
-		panes = append(panes, s.NewFromSpec(ctx, s))
+		p := s.NewFromSpec(ctx, s)
+		if t, ok := p.(*table.Model); ok {
+			t.SetLayoutKey(fmt.Sprintf("%s/%s", spec.Name, s.GetName()))
+		}
+		panes = append(panes, p)
 	}
 	return Model{
-		ctx:   ctx,
-		Spec:  spec,
-		Tabs:  tabs.NewModel(ctx, tabsList),
-		Panes: panes,
+		ctx:    ctx,
+		Spec:   spec,
+		Tabs:   tabs.NewModel(ctx, tabsList),
+		Panes:  panes,
+		Errors: map[int]FetchErrorMsg{},
 	}
 }
 
@@ -105,18 +150,56 @@ func (m *Model) Init() tea.Cmd {
 }
 
 func (m *Model) View() string {
-	pane := m.Panes[m.Tabs.CurrentTabId]
-
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		m.Tabs.View(),
-		pane.View(),
+		m.CurrentPaneView(),
 	)
 }
 
+// Renders the current pane, or the error from its last fetch if it failed
+func (m *Model) CurrentPaneView() string {
+	if msg, ok := m.Errors[m.Tabs.CurrentTabId]; ok {
+		return m.renderError(msg.Err)
+	}
+	return m.CurrentPane().View()
+}
+
+func (m *Model) renderError(err error) string {
+	details := data.GetErrorDetails(err)
+
+	title := "Error"
+	if details.Code != "" {
+		title = details.Code
+	}
+	lines := []string{
+		errorTitleStyle.Render(title),
+		errorTextStyle.Render(details.Message),
+	}
+	if details.RequestId != "" {
+		lines = append(lines, errorFaintTextStyle.Render(fmt.Sprintf("Request ID: %s", details.RequestId)))
+	}
+	lines = append(lines,
+		"",
+		errorFaintTextStyle.Render(err.Error()),
+		"",
+		errorFaintTextStyle.Render(fmt.Sprintf("Press %s to retry", m.ctx.Keys.Refresh.Help().Key)),
+	)
+
+	return errorStyle.Copy().
+		Width(m.width).
+		MaxWidth(m.width).
+		Height(m.height).
+		MaxHeight(m.height).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
 func (m *Model) SetSize(width int, height int) {
+	m.width = width
+	m.height = height - tabs.TabsHeight - help.HelpHeight
+	m.Tabs.SetWidth(width)
 	for _, p := range m.Panes {
-		p.SetSize(width, height-tabs.TabsHeight-help.HelpHeight)
+		p.SetSize(m.width, m.height)
 	}
 }
 
@@ -124,6 +207,16 @@ func (m *Model) CurrentPane() pane.Pane {
 	return m.Panes[m.Tabs.CurrentTabId]
 }
 
+// The selected row of the current pane, or false if it isn't a table or has no rows
+func (m *Model) CurrentRow() (map[string]string, bool) {
+	table, ok := m.CurrentPane().(*table.Model)
+	if !ok {
+		return nil, false
+	}
+	row := table.GetCurrentRowMarshalled()
+	return row, row != nil
+}
+
 func (m *Model) NextTab() int {
 	m.CurrentPane().Hide()
 	return m.Tabs.NextTab()
@@ -154,7 +247,12 @@ func (m *Model) FetchData(client *data.Client) tea.Cmd {
 	return nil
 }
 
+func (m *Model) FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd {
+	return nil
+}
+
 func (m *Model) ClearData() {
+	m.Errors = map[int]FetchErrorMsg{}
 	for _, pane := range m.Panes {
 		table, ok := pane.(*table.Model)
 		if ok {
@@ -175,6 +273,21 @@ func (m *Model) GetSpec() PageSpec {
 	return m.Spec
 }
 
+func (m *Model) SetError(msg FetchErrorMsg) {
+	m.Errors[msg.PaneId] = msg
+}
+
+// Clears the error on the current pane and returns the command that failed, or nil if the pane
+// has no error. Rows already loaded (e.g. earlier pages) are kept.
+func (m *Model) RetryCurrentPane() tea.Cmd {
+	msg, ok := m.Errors[m.Tabs.CurrentTabId]
+	if !ok {
+		return nil
+	}
+	delete(m.Errors, m.Tabs.CurrentTabId)
+	return msg.RetryCmd
+}
+
 func (m *Model) GetPaneId(paneName string) int {
 	for i, p := range m.Panes {
 		if p.GetSpec().GetName() == paneName {
@@ -235,6 +348,15 @@ func (m *Model) GetCurrentPaneId() int {
 	return m.Tabs.CurrentTabId
 }
 
+// Switches to the tab of pane id, ignored if there's no such pane
+func (m *Model) SetCurrentPaneId(id int) {
+	if id < 0 || id >= len(m.Panes) || id == m.Tabs.CurrentTabId {
+		return
+	}
+	m.CurrentPane().Hide()
+	m.Tabs.CurrentTabId = id
+}
+
 func (m *Model) Hide() {
 	pane := m.CurrentPane()
 	table, ok := pane.(*table.Model)
@@ -0,0 +1,30 @@
+package page
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danielcmessias/sawsy/ui/styles"
+)
+
+var (
+	errorStyle = lipgloss.NewStyle().
+			PaddingLeft(1).
+			PaddingTop(1)
+
+	errorTitleStyle     lipgloss.Style
+	errorTextStyle      lipgloss.Style
+	errorFaintTextStyle lipgloss.Style
+)
+
+func init() {
+	styles.OnThemeChange(func() {
+		errorTitleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(styles.Theme.ErrorText)
+
+		errorTextStyle = lipgloss.NewStyle().
+			Foreground(styles.Theme.MainText)
+
+		errorFaintTextStyle = lipgloss.NewStyle().
+			Foreground(styles.Theme.FaintText)
+	})
+}
@@ -0,0 +1,166 @@
+package palette
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danielcmessias/sawsy/ui/context"
+	"github.com/danielcmessias/sawsy/utils"
+)
+
+// Something that can be picked from the palette
+type Item struct {
+	Title string
+	// Shown faintly next to the title, e.g. "page" or "command"
+	Kind  string
+	Value interface{}
+}
+
+// A `:` prompt listing the items fuzzily matching what's been typed, k9s style. It has the
+// keyboard to itself while it's open.
+type Model struct {
+	ctx     *context.ProgramContext
+	input   textinput.Model
+	items   []Item
+	matches []Item
+	cursor  int
+}
+
+// Sent when the palette is submitted with enter. Item is the highlighted match, or nil if nothing
+// matched, in which case Value is whatever was typed.
+type SubmitMsg struct {
+	Item  *Item
+	Value string
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	input := textinput.New()
+	input.Prompt = ":"
+	input.PromptStyle = promptStyle
+	return Model{
+		ctx:   ctx,
+		input: input,
+	}
+}
+
+func (m *Model) Open(items []Item) tea.Cmd {
+	m.items = items
+	m.input.Reset()
+	m.match()
+	m.ctx.LockKeyboardCapture = true
+	return m.input.Focus()
+}
+
+func (m *Model) Close() {
+	m.input.Blur()
+	m.ctx.LockKeyboardCapture = false
+}
+
+func (m Model) Focused() bool {
+	return m.input.Focused()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.input.Focused() {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.Close()
+			submitted := SubmitMsg{Value: strings.TrimSpace(m.input.Value())}
+			if len(m.matches) > 0 {
+				item := m.matches[m.cursor]
+				submitted.Item = &item
+			}
+			return m, func() tea.Msg {
+				return submitted
+			}
+		case tea.KeyEsc:
+			m.Close()
+			return m, nil
+		case tea.KeyUp, tea.KeyCtrlP:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case tea.KeyDown, tea.KeyCtrlN:
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case tea.KeyTab:
+			// Complete to the highlighted match, e.g. to edit a recent resource into another
+			if len(m.matches) > 0 {
+				m.input.SetValue(m.matches[m.cursor].Title)
+				m.input.CursorEnd()
+				m.match()
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.match()
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var lines []string
+	for i, item := range m.shown() {
+		style := itemStyle
+		marker := "  "
+		if i == m.cursor {
+			style = selectedItemStyle
+			marker = "> "
+		}
+		lines = append(lines, style.Render(marker+item.Title)+" "+kindStyle.Render(item.Kind))
+	}
+	lines = append(lines, m.input.View())
+
+	return paletteStyle.Copy().
+		Width(m.ctx.ScreenWidth).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// Lines taken up by the palette, including its border
+func (m Model) Height() int {
+	return len(m.shown()) + 2
+}
+
+func (m Model) shown() []Item {
+	if len(m.matches) > maxShown {
+		return m.matches[:maxShown]
+	}
+	return m.matches
+}
+
+// Ranks the items by how well they match the input, dropping those that don't at all
+func (m *Model) match() {
+	query := strings.TrimSpace(m.input.Value())
+	scores := map[int]int{}
+	var matched []int
+	for i, item := range m.items {
+		if score, _, ok := utils.FuzzyMatch(query, item.Title); ok {
+			scores[i] = score
+			matched = append(matched, i)
+		}
+	}
+	// Ties keep the order the items were given in
+	sort.SliceStable(matched, func(a, b int) bool {
+		return scores[matched[a]] > scores[matched[b]]
+	})
+
+	m.matches = make([]Item, len(matched))
+	for i, j := range matched {
+		m.matches[i] = m.items[j]
+	}
+	m.cursor = 0
+}
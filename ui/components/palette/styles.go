@@ -0,0 +1,32 @@
+package palette
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danielcmessias/sawsy/ui/styles"
+)
+
+var (
+	// How many matches are listed at once
+	maxShown = 8
+
+	promptStyle       lipgloss.Style
+	paletteStyle      lipgloss.Style
+	itemStyle         lipgloss.Style
+	selectedItemStyle lipgloss.Style
+	kindStyle         lipgloss.Style
+)
+
+func init() {
+	styles.OnThemeChange(func() {
+		promptStyle = lipgloss.NewStyle().Foreground(styles.Theme.SearchPrompt)
+
+		paletteStyle = lipgloss.NewStyle().
+			BorderTop(true).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(styles.Theme.Border)
+
+		itemStyle = lipgloss.NewStyle().Foreground(styles.Theme.MainText)
+		selectedItemStyle = lipgloss.NewStyle().Foreground(styles.Theme.HighlightRow).Bold(true)
+		kindStyle = lipgloss.NewStyle().Foreground(styles.Theme.FaintText)
+	})
+}
@@ -0,0 +1,80 @@
+package prompt
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/ui/context"
+	"github.com/danielcmessias/sawsy/ui/styles"
+)
+
+// A line of input shown in the footer, in place of the help, which has the keyboard to itself
+// while it's open
+type Model struct {
+	ctx   *context.ProgramContext
+	input textinput.Model
+	id    string
+}
+
+// Sent when the prompt is submitted with enter. Id is whatever the prompt was opened with, to
+// tell prompts apart.
+type SubmitMsg struct {
+	Id    string
+	Value string
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	input := textinput.New()
+	input.PromptStyle = promptStyle
+	return Model{
+		ctx:   ctx,
+		input: input,
+	}
+}
+
+func (m *Model) Open(id string, prompt string, placeholder string) tea.Cmd {
+	m.id = id
+	m.input.Prompt = prompt
+	m.input.Placeholder = placeholder
+	m.input.Reset()
+	m.ctx.LockKeyboardCapture = true
+	return m.input.Focus()
+}
+
+func (m *Model) Close() {
+	m.input.Blur()
+	m.ctx.LockKeyboardCapture = false
+}
+
+func (m Model) Focused() bool {
+	return m.input.Focused()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.input.Focused() {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.Close()
+			submitted := SubmitMsg{Id: m.id, Value: m.input.Value()}
+			return m, func() tea.Msg {
+				return submitted
+			}
+		case tea.KeyEsc:
+			m.Close()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	return styles.FooterStyle.Copy().
+		Width(m.ctx.ScreenWidth).
+		Render(m.input.View())
+}
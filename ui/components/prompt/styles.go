@@ -0,0 +1,14 @@
+package prompt
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danielcmessias/sawsy/ui/styles"
+)
+
+var promptStyle lipgloss.Style
+
+func init() {
+	styles.OnThemeChange(func() {
+		promptStyle = lipgloss.NewStyle().Foreground(styles.Theme.SearchPrompt)
+	})
+}
@@ -0,0 +1,242 @@
+package table
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/danielcmessias/sawsy/config"
+)
+
+// What a table cell holds. Data clients give the value and the table decides how it's shown, while
+// sorting, comparing and exporting by the value itself. One of:
+//
+//	string         shown as is
+//	ARN            shown as is
+//	Bytes          shown like 1.2 MB
+//	time.Time      in the configured format and time zone, the zero time is blank
+//	time.Duration  shown like 3m 12s
+//	bool           Yes or No
+//	int, int32, int64, float64
+type Cell interface{}
+
+// A size in bytes
+type Bytes int64
+
+// An Amazon Resource Name
+type ARN string
+
+// The API object a row was made from, kept after its columns
+type objectCell struct {
+	value interface{}
+}
+
+// The row, with the object it was made from kept alongside
+func WithObject(row Row, object interface{}) Row {
+	return append(row, objectCell{value: object})
+}
+
+// The object the row was made from, if it kept one
+func (r Row) Object() (interface{}, bool) {
+	if len(r) == 0 {
+		return nil, false
+	}
+	cell, ok := r[len(r)-1].(objectCell)
+	return cell.value, ok
+}
+
+// The row's cells, without its object
+func (r Row) cells() Row {
+	if _, ok := r.Object(); ok {
+		return r[:len(r)-1]
+	}
+	return r
+}
+
+// How cells are shown
+type CellFormat struct {
+	TimeLayout string         // Empty for relative times, e.g. 3h ago
+	Location   *time.Location // Times are left in their own if nil
+	RawSizes   bool           // Sizes in bytes, rather than e.g. 1.2 MB
+}
+
+var DefaultCellFormat = CellFormat{TimeLayout: TIME_FORMAT}
+
+// The cell format set in the config, or an error if the time zone doesn't exist
+func NewCellFormat(c config.FormatConfig) (CellFormat, error) {
+	format := DefaultCellFormat
+	format.RawSizes = c.RawSizes
+
+	switch c.Time {
+	case "":
+	case "relative":
+		format.TimeLayout = ""
+	default:
+		format.TimeLayout = c.Time
+	}
+
+	if c.TimeZone != "" {
+		location, err := time.LoadLocation(c.TimeZone)
+		if err != nil {
+			return DefaultCellFormat, fmt.Errorf("unknown time zone %q: %w", c.TimeZone, err)
+		}
+		format.Location = location
+	}
+	return format, nil
+}
+
+func (f CellFormat) Format(cell Cell) string {
+	switch v := cell.(type) {
+	case nil, objectCell:
+		return ""
+	case string:
+		return v
+	case ARN:
+		return string(v)
+	case Bytes:
+		if f.RawSizes {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return formatBytes(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		if f.Location != nil {
+			v = v.In(f.Location)
+		}
+		if f.TimeLayout == "" {
+			return formatRelativeTime(v, time.Now())
+		}
+		return v.Format(f.TimeLayout)
+	case time.Duration:
+		return formatDuration(v)
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	}
+	return RawText(cell)
+}
+
+func (f CellFormat) FormatRow(row Row) []string {
+	row = row.cells()
+	text := make([]string, len(row))
+	for i, cell := range row {
+		text[i] = f.Format(cell)
+	}
+	return text
+}
+
+// The value with nothing lost to formatting, as exported. Sizes are in bytes, times RFC 3339 and
+// durations like 1m30s.
+func RawText(cell Cell) string {
+	switch v := cell.(type) {
+	case nil, objectCell:
+		return ""
+	case string:
+		return v
+	case ARN:
+		return string(v)
+	case Bytes:
+		return strconv.FormatInt(int64(v), 10)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case time.Duration:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(cell)
+}
+
+// The value as it's written to JSON, numbers and booleans as they are and the rest as RawText
+func rawValue(cell Cell) interface{} {
+	switch v := cell.(type) {
+	case Bytes:
+		return int64(v)
+	case bool, int, int32, int64, float64:
+		return v
+	}
+	return RawText(cell)
+}
+
+func numberValue(cell Cell) (float64, bool) {
+	switch v := cell.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+var sizeUnits = []string{"B", "KB", "MB", "GB", "TB", "PB"}
+
+func formatBytes(b Bytes) string {
+	size := float64(b)
+	unit := 0
+	for size >= 1000 && unit < len(sizeUnits)-1 {
+		size /= 1000
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d B", b)
+	}
+	return fmt.Sprintf("%.1f %s", size, sizeUnits[unit])
+}
+
+// e.g. 45s, 3m 12s or 2h 5m 0s
+func formatDuration(d time.Duration) string {
+	if d < time.Second && d > -time.Second {
+		return d.String()
+	}
+	d = d.Round(time.Second)
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)
+	switch {
+	case h != 0:
+		return fmt.Sprintf("%dh %dm %ds", h, m, s)
+	case m != 0:
+		return fmt.Sprintf("%dm %ds", m, s)
+	}
+	return fmt.Sprintf("%ds", s)
+}
+
+// e.g. 5m ago, 3d ago or in 2h
+func formatRelativeTime(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	suffix := " ago"
+	if d < 0 {
+		d, suffix = -d, ""
+	}
+
+	var text string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		text = fmt.Sprintf("%dm", d/time.Minute)
+	case d < 24*time.Hour:
+		text = fmt.Sprintf("%dh", d/time.Hour)
+	case d < 30*24*time.Hour:
+		text = fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d < 365*24*time.Hour:
+		text = fmt.Sprintf("%dmo", d/(30*24*time.Hour))
+	default:
+		text = fmt.Sprintf("%dy", d/(365*24*time.Hour))
+	}
+	if suffix == "" {
+		return "in " + text
+	}
+	return text + suffix
+}
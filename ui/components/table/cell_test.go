@@ -0,0 +1,191 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/danielcmessias/sawsy/config"
+)
+
+var testTime = time.Date(2022, 12, 31, 23, 59, 58, 0, time.UTC)
+
+func TestFormat(t *testing.T) {
+	plus2 := time.FixedZone("UTC+2", 2*60*60)
+	tests := []struct {
+		name   string
+		format CellFormat
+		cell   Cell
+		want   string
+	}{
+		{"nil", DefaultCellFormat, nil, ""},
+		{"string", DefaultCellFormat, "hello", "hello"},
+		{"arn", DefaultCellFormat, ARN("arn:aws:iam::123:role/a"), "arn:aws:iam::123:role/a"},
+		{"bytes", DefaultCellFormat, Bytes(999), "999 B"},
+		{"kilobytes", DefaultCellFormat, Bytes(1500), "1.5 KB"},
+		{"gigabytes", DefaultCellFormat, Bytes(2_340_000_000), "2.3 GB"},
+		{"huge bytes", DefaultCellFormat, Bytes(5e18), "5000.0 PB"},
+		{"raw bytes", CellFormat{RawSizes: true}, Bytes(1500), "1500"},
+		{"time", DefaultCellFormat, testTime, "31/12/2022 23:59:58"},
+		{"zero time", DefaultCellFormat, time.Time{}, ""},
+		{"time layout", CellFormat{TimeLayout: "2006-01-02 15:04"}, testTime, "2022-12-31 23:59"},
+		{"time zone", CellFormat{TimeLayout: TIME_FORMAT, Location: plus2}, testTime, "01/01/2023 01:59:58"},
+		{"milliseconds", DefaultCellFormat, 250 * time.Millisecond, "250ms"},
+		{"seconds", DefaultCellFormat, 45 * time.Second, "45s"},
+		{"minutes", DefaultCellFormat, 3*time.Minute + 12*time.Second, "3m 12s"},
+		{"hours", DefaultCellFormat, 2*time.Hour + 5*time.Minute, "2h 5m 0s"},
+		{"rounded", DefaultCellFormat, 59*time.Second + 600*time.Millisecond, "1m 0s"},
+		{"true", DefaultCellFormat, true, "Yes"},
+		{"false", DefaultCellFormat, false, "No"},
+		{"int", DefaultCellFormat, 42, "42"},
+		{"int32", DefaultCellFormat, int32(-7), "-7"},
+		{"float", DefaultCellFormat, 1.25, "1.25"},
+		{"object", DefaultCellFormat, objectCell{value: "x"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.format.Format(tt.cell); got != tt.want {
+			t.Errorf("%s: Format(%v) = %q, want %q", tt.name, tt.cell, got, tt.want)
+		}
+	}
+}
+
+func TestRawText(t *testing.T) {
+	tests := []struct {
+		cell Cell
+		want string
+	}{
+		{nil, ""},
+		{"a b", "a b"},
+		{ARN("arn:aws:s3:::b"), "arn:aws:s3:::b"},
+		{Bytes(1500), "1500"},
+		{testTime.In(time.FixedZone("UTC+2", 2*60*60)), "2023-01-01T01:59:58+02:00"},
+		{time.Time{}, ""},
+		{90 * time.Second, "1m30s"},
+		{true, "true"},
+		{int64(3), "3"},
+		{0.1, "0.1"},
+	}
+	for _, tt := range tests {
+		if got := RawText(tt.cell); got != tt.want {
+			t.Errorf("RawText(%v) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := testTime
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-3 * time.Hour), "3h ago"},
+		{now.Add(-3 * 24 * time.Hour), "3d ago"},
+		{now.Add(-65 * 24 * time.Hour), "2mo ago"},
+		{now.Add(-800 * 24 * time.Hour), "2y ago"},
+		{now.Add(2 * time.Hour), "in 2h"},
+	}
+	for _, tt := range tests {
+		if got := formatRelativeTime(tt.t, now); got != tt.want {
+			t.Errorf("formatRelativeTime(%v) = %q, want %q", now.Sub(tt.t), got, tt.want)
+		}
+	}
+}
+
+func TestNewCellFormat(t *testing.T) {
+	format, err := NewCellFormat(config.FormatConfig{Time: "relative", TimeZone: "UTC", RawSizes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format.TimeLayout != "" || format.Location != time.UTC || !format.RawSizes {
+		t.Errorf("NewCellFormat = %+v", format)
+	}
+
+	format, _ = NewCellFormat(config.FormatConfig{})
+	if !reflect.DeepEqual(format, DefaultCellFormat) {
+		t.Errorf("NewCellFormat of nothing = %+v, want the default", format)
+	}
+
+	if _, err := NewCellFormat(config.FormatConfig{TimeZone: "Nowhere/Special"}); err == nil {
+		t.Error("expected an error for an unknown time zone")
+	}
+}
+
+func TestFormatRowDropsObject(t *testing.T) {
+	row := WithObject(Row{"a", Bytes(1)}, struct{}{})
+	if got := DefaultCellFormat.FormatRow(row); !reflect.DeepEqual(got, []string{"a", "1 B"}) {
+		t.Errorf("FormatRow = %q", got)
+	}
+	if _, ok := row.Object(); !ok {
+		t.Error("row lost its object")
+	}
+	if _, ok := (Row{"a"}).Object(); ok {
+		t.Error("row without an object has one")
+	}
+}
+
+func TestCellSortKey(t *testing.T) {
+	tests := []struct {
+		name string
+		cell Cell
+		kind cellKind
+		// Only compared for kinds other than text and blank
+		value float64
+	}{
+		{"bytes", Bytes(1500), bytesCell, 1500},
+		{"time", testTime, timeCell, float64(testTime.UnixNano())},
+		{"zero time", time.Time{}, blankCell, 0},
+		{"duration", time.Minute, durationCell, float64(time.Minute)},
+		{"int", 7, numberCell, 7},
+		{"float", -1.5, numberCell, -1.5},
+		{"text", "abc", textCell, 0},
+		{"nil", nil, blankCell, 0},
+		{"empty", "  ", blankCell, 0},
+		{"loading", "...", blankCell, 0},
+		{"number text", "1,024", numberCell, 1024},
+		{"percentage", "12.5%", numberCell, 12.5},
+		{"size text", "1.5 KB", bytesCell, 1500},
+		{"binary size text", "2 MiB", bytesCell, 2 << 20},
+		{"duration text", "3m 12s", durationCell, float64(3*time.Minute + 12*time.Second)},
+		{"time text", "31/12/2022 23:59:58", timeCell, float64(testTime.UnixNano())},
+		{"rfc 3339 text", "2022-12-31T23:59:58Z", timeCell, float64(testTime.UnixNano())},
+		{"arn", ARN("arn:aws:iam::123:role/a"), textCell, 0},
+	}
+	for _, tt := range tests {
+		key := cellSortKey(tt.cell, DefaultCellFormat.Format(tt.cell))
+		if key.kind != tt.kind {
+			t.Errorf("%s: kind %v, want %v", tt.name, key.kind, tt.kind)
+			continue
+		}
+		if key.kind != textCell && key.kind != blankCell && key.value != tt.value {
+			t.Errorf("%s: value %v, want %v", tt.name, key.value, tt.value)
+		}
+	}
+}
+
+func TestSortKeyOrder(t *testing.T) {
+	keys := func(cells ...Cell) []sortKey {
+		var k []sortKey
+		for _, c := range cells {
+			k = append(k, cellSortKey(c, DefaultCellFormat.Format(c)))
+		}
+		return k
+	}
+
+	// Typed values compare by value, not as shown: 999 B sorts before 1.0 KB
+	ordered := keys(Bytes(999), Bytes(1000), Bytes(2_000_000))
+	for i := 1; i < len(ordered); i++ {
+		if !lessSortKey(ordered[i-1], ordered[i]) {
+			t.Errorf("%v not before %v", ordered[i-1], ordered[i])
+		}
+	}
+
+	// Text ignores case, and kinds are grouped, text first
+	ordered = keys("apple", "Banana", 10)
+	for i := 1; i < len(ordered); i++ {
+		if !lessSortKey(ordered[i-1], ordered[i]) {
+			t.Errorf("%v not before %v", ordered[i-1], ordered[i])
+		}
+	}
+}
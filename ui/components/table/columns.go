@@ -0,0 +1,242 @@
+package table
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danielcmessias/sawsy/config"
+	"github.com/danielcmessias/sawsy/ui/components/toast"
+	"github.com/danielcmessias/sawsy/utils"
+)
+
+// A column in view, and how wide it's drawn
+type shownColumn struct {
+	id    int
+	width int
+}
+
+// Sets where the table's column layout is kept in the config, tables without one can't be
+// rearranged
+func (m *Model) SetLayoutKey(key string) {
+	m.layoutKey = key
+}
+
+func (m *Model) tableConfig() config.TableConfig {
+	if m.layoutKey == "" || m.ctx.Config == nil {
+		return config.TableConfig{}
+	}
+	return m.ctx.Config.Tables[m.layoutKey]
+}
+
+// Every column, hidden ones included, in the order chosen for the table
+func (m *Model) columnOrder() []int {
+	ids := make(map[string]int, len(m.Columns))
+	for i, c := range m.Columns {
+		ids[c.Title] = i
+	}
+
+	order := make([]int, 0, len(m.Columns))
+	placed := make([]bool, len(m.Columns))
+	for _, title := range m.tableConfig().Columns {
+		if i, ok := ids[title]; ok && !placed[i] {
+			order = append(order, i)
+			placed[i] = true
+		}
+	}
+	for i := range m.Columns {
+		if !placed[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// The columns that aren't hidden, in order
+func (m *Model) visibleColumns() []int {
+	hidden := make(map[string]bool)
+	for _, title := range m.tableConfig().Hidden {
+		hidden[title] = true
+	}
+
+	order := m.columnOrder()
+	visible := make([]int, 0, len(order))
+	for _, i := range order {
+		if !hidden[m.Columns[i].Title] {
+			visible = append(visible, i)
+		}
+	}
+	// Something has to be shown, even if the config hides it all
+	if len(visible) == 0 {
+		return order
+	}
+	return visible
+}
+
+func (m *Model) columnTitle(id int) string {
+	title := m.Columns[id].Title
+	if m.sortOrder != unsorted && id == m.sortColumnId {
+		title += sortIndicators[m.sortOrder]
+	}
+	return title
+}
+
+// As wide as the widest cell or the title, up to MaxWidth
+func (m *Model) columnWidth(id int) int {
+	width := utils.Max(m.colMaxWidths[id], lipgloss.Width(titleCellStyle.Render(m.columnTitle(id))))
+	if max := m.Columns[id].MaxWidth; max != nil {
+		width = utils.Min(width, *max)
+	}
+	return width
+}
+
+// Works out which columns fit, scrolling sideways as little as possible to keep the selected one
+// in view. The last one is cut short if it doesn't fit.
+func (m *Model) layoutColumns() []shownColumn {
+	visible := m.visibleColumns()
+	if len(visible) == 0 {
+		m.rowsViewport.Columns = ""
+		return nil
+	}
+	widths := make([]int, len(visible))
+	for p, id := range visible {
+		widths[p] = utils.Min(m.columnWidth(id), m.width)
+	}
+
+	selected := indexOf(visible, m.currColumnId)
+	if selected < 0 {
+		selected = 0
+		m.currColumnId = visible[0]
+	}
+
+	m.colOffset = utils.Max(utils.Min(m.colOffset, selected), 0)
+	for m.colOffset < selected && utils.Sum(widths[m.colOffset:selected+1]) > m.width {
+		m.colOffset++
+	}
+	// Scroll back when there's room, e.g. after the terminal got wider
+	for m.colOffset > 0 && utils.Sum(widths[m.colOffset-1:]) <= m.width {
+		m.colOffset--
+	}
+
+	var shown []shownColumn
+	remaining := m.width
+	for p := m.colOffset; p < len(visible) && remaining > 0; p++ {
+		width := utils.Min(widths[p], remaining)
+		shown = append(shown, shownColumn{id: visible[p], width: width})
+		remaining -= width
+	}
+
+	m.rowsViewport.Columns = ""
+	if hidden := len(m.Columns) - len(visible); len(shown) < len(visible) || hidden > 0 {
+		m.rowsViewport.Columns = fmt.Sprintf(
+			"columns %d-%d of %d",
+			m.colOffset+1,
+			m.colOffset+len(shown),
+			len(visible),
+		)
+		if hidden > 0 {
+			m.rowsViewport.Columns += fmt.Sprintf(", %d hidden", hidden)
+		}
+	}
+
+	return shown
+}
+
+func (m *Model) nextCol() {
+	m.selectCol(1)
+}
+
+func (m *Model) prevCol() {
+	m.selectCol(-1)
+}
+
+func (m *Model) selectCol(delta int) {
+	visible := m.visibleColumns()
+	if len(visible) == 0 {
+		return
+	}
+	p := utils.Max(indexOf(visible, m.currColumnId), 0)
+	m.currColumnId = visible[(p+delta+len(visible))%len(visible)]
+}
+
+// Swaps the selected column with its neighbour
+func (m *Model) moveCol(delta int) tea.Cmd {
+	visible := m.visibleColumns()
+	p := indexOf(visible, m.currColumnId)
+	if p < 0 || p+delta < 0 || p+delta >= len(visible) {
+		return nil
+	}
+
+	order := m.columnOrder()
+	a, b := indexOf(order, visible[p]), indexOf(order, visible[p+delta])
+	order[a], order[b] = order[b], order[a]
+
+	tableConfig := m.tableConfig()
+	tableConfig.Columns = make([]string, len(order))
+	for i, id := range order {
+		tableConfig.Columns[i] = m.Columns[id].Title
+	}
+	return m.setTableConfig(&tableConfig)
+}
+
+func (m *Model) hideCol() tea.Cmd {
+	visible := m.visibleColumns()
+	if len(visible) < 2 {
+		return toast.Show("The last column can't be hidden")
+	}
+
+	tableConfig := m.tableConfig()
+	tableConfig.Hidden = append(
+		append([]string{}, tableConfig.Hidden...),
+		m.Columns[m.currColumnId].Title,
+	)
+
+	p := utils.Max(indexOf(visible, m.currColumnId), 0)
+	if p+1 < len(visible) {
+		m.currColumnId = visible[p+1]
+	} else {
+		m.currColumnId = visible[p-1]
+	}
+	return m.setTableConfig(&tableConfig)
+}
+
+// Shows every column again, in the usual order
+func (m *Model) resetCols() tea.Cmd {
+	return m.setTableConfig(nil)
+}
+
+// Changes the layout of the table, and of any other copy of it, and saves it to the config file
+func (m *Model) setTableConfig(tableConfig *config.TableConfig) tea.Cmd {
+	if m.layoutKey == "" || m.ctx.Config == nil {
+		return nil
+	}
+
+	if tableConfig == nil {
+		delete(m.ctx.Config.Tables, m.layoutKey)
+	} else {
+		if m.ctx.Config.Tables == nil {
+			m.ctx.Config.Tables = make(map[string]config.TableConfig)
+		}
+		m.ctx.Config.Tables[m.layoutKey] = *tableConfig
+	}
+
+	key := m.layoutKey
+	return func() tea.Msg {
+		if err := config.SaveTableConfig(key, tableConfig); err != nil {
+			return toast.ShowMsg{
+				Message: fmt.Sprintf("Couldn't save the column layout: %v", err),
+				IsError: true,
+			}
+		}
+		return nil
+	}
+}
+
+func indexOf(ids []int, id int) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,47 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/danielcmessias/sawsy/config"
+)
+
+func TestLayoutWithoutColumns(t *testing.T) {
+	m := newTestTable()
+	m.AppendRows([]Row{{}})
+	m.View()
+	m.nextCol()
+	m.hideCol()
+	if cmd := m.copyCell(); cmd != nil {
+		t.Error("copied a cell from a table without columns")
+	}
+}
+
+func TestLayoutWithEveryColumnHidden(t *testing.T) {
+	m := newTestTable("Name", "Size")
+	m.ctx.Config = &config.Config{Tables: map[string]config.TableConfig{
+		"test": {Hidden: []string{"Name", "Size"}},
+	}}
+	m.SetLayoutKey("test")
+	m.AppendRows([]Row{{"a", "1"}})
+	m.View()
+
+	if shown := m.layoutColumns(); len(shown) != 2 {
+		t.Errorf("showed %d columns, want both", len(shown))
+	}
+}
+
+func TestHideLastColumn(t *testing.T) {
+	m := newTestTable("Name", "Size")
+	m.ctx.Config = &config.Config{}
+	m.SetLayoutKey("test")
+	m.ctx.Config.Tables = map[string]config.TableConfig{"test": {Hidden: []string{"Size"}}}
+
+	m.hideCol()
+	if hidden := m.ctx.Config.Tables["test"].Hidden; len(hidden) != 1 {
+		t.Errorf("hidden = %v, want only Size", hidden)
+	}
+	if shown := m.layoutColumns(); len(shown) != 1 || m.Columns[shown[0].id].Title != "Name" {
+		t.Errorf("shown = %v, want Name", shown)
+	}
+}
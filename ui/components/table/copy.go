@@ -0,0 +1,65 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/ui/components/toast"
+	"github.com/danielcmessias/sawsy/utils/clipboard"
+)
+
+// Copies the selected cell, as it's exported
+func (m *Model) copyCell() tea.Cmd {
+	row := m.GetCurrentRow()
+	if row == nil || len(m.Columns) == 0 {
+		return nil
+	}
+	return copyText(RawText(cellAt(row, m.currColumnId)), m.Columns[m.currColumnId].Title)
+}
+
+// Copies the marked rows, or the selected one, a line each with tabs between the cells
+func (m *Model) copyRowsTSV() tea.Cmd {
+	rows := m.GetActionRows()
+	if len(rows) == 0 {
+		return nil
+	}
+	clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ")
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		cells := make([]string, len(m.Columns))
+		for j := range m.Columns {
+			cells[j] = clean.Replace(RawText(cellAt(row, j)))
+		}
+		lines[i] = strings.Join(cells, "\t")
+	}
+	return copyText(strings.Join(lines, "\n"), rowsLabel(len(rows)))
+}
+
+// Copies the marked rows, or the selected one, as they're exported to JSON
+func (m *Model) copyRowsJSON() tea.Cmd {
+	rows := m.GetActionRows()
+	if len(rows) == 0 {
+		return nil
+	}
+	columns := make([]string, len(m.Columns))
+	for i, c := range m.Columns {
+		columns[i] = c.Title
+	}
+	var b strings.Builder
+	if err := writeJSON(&b, columns, rows); err != nil {
+		return toast.ShowError(fmt.Sprintf("Couldn't copy: %v", err))
+	}
+	return copyText(b.String(), rowsLabel(len(rows))+" as JSON")
+}
+
+func rowsLabel(n int) string {
+	if n == 1 {
+		return "1 row"
+	}
+	return fmt.Sprintf("%d rows", n)
+}
+
+func copyText(text string, what string) tea.Cmd {
+	return clipboard.Copy(text, toast.ShowMsg{Message: fmt.Sprintf("Copied %s", what)})
+}
@@ -0,0 +1,233 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/ui/components/toast"
+)
+
+type rowWriter func(out io.Writer, columns []string, rows []Row) error
+
+var exportFormats = map[string]rowWriter{
+	"csv":      writeCSV,
+	"json":     writeJSON,
+	"markdown": writeMarkdown,
+}
+
+var exportExtensions = map[string]string{
+	".csv":      "csv",
+	".json":     "json",
+	".md":       "markdown",
+	".markdown": "markdown",
+}
+
+func ExportFormats() []string {
+	var formats []string
+	for f := range exportFormats {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// The export format for a file, based on its extension. Empty if there isn't one.
+func ExportFormatForPath(path string) string {
+	return exportExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+// Writes rows with the column titles as headers, in one of ExportFormats
+func WriteRows(out io.Writer, format string, columns []string, rows []Row) error {
+	write, ok := exportFormats[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(ExportFormats(), ", "))
+	}
+	return write(out, columns, rows)
+}
+
+// An array of objects keyed by column title, keeping the columns in order
+func writeJSON(out io.Writer, columns []string, rows []Row) error {
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("{")
+		for j, title := range columns {
+			if j > 0 {
+				buf.WriteString(",")
+			}
+			k, _ := json.Marshal(title)
+			v, _ := json.Marshal(rawValue(cellAt(row, j)))
+			buf.Write(k)
+			buf.WriteString(":")
+			buf.Write(v)
+		}
+		buf.WriteString("}")
+	}
+	buf.WriteString("]")
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	indented.WriteString("\n")
+	_, err := indented.WriteTo(out)
+	return err
+}
+
+func writeCSV(out io.Writer, columns []string, rows []Row) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for j := range columns {
+			record[j] = RawText(cellAt(row, j))
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func writeMarkdown(out io.Writer, columns []string, rows []Row) error {
+	escape := strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+	line := func(cells []string) string {
+		for i, c := range cells {
+			cells[i] = escape.Replace(c)
+		}
+		return "| " + strings.Join(cells, " | ") + " |\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(line(append([]string{}, columns...)))
+	separators := make([]string, len(columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	b.WriteString(line(separators))
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for j := range columns {
+			cells[j] = RawText(cellAt(row, j))
+		}
+		b.WriteString(line(cells))
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// Some rows are shorter than the columns, e.g. while a value is still loading
+func cellAt(row Row, i int) Cell {
+	if i < len(row) {
+		return row[i]
+	}
+	return nil
+}
+
+// Like cellAt, for the cells of a row as they're shown
+func textAt(texts []string, i int) string {
+	if i < len(texts) {
+		return texts[i]
+	}
+	return ""
+}
+
+// Opens the prompt asking where to export the table to
+func (m *Model) StartExport() tea.Cmd {
+	m.exportPrompt.SetValue(m.defaultExportPath())
+	m.exportPrompt.CursorEnd()
+	m.ctx.LockKeyboardCapture = true
+	return m.exportPrompt.Focus()
+}
+
+func (m *Model) updateExportPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.exportPrompt.Blur()
+		m.ctx.LockKeyboardCapture = false
+		return m.export(m.exportPrompt.Value())
+	case tea.KeyEsc:
+		m.exportPrompt.Blur()
+		m.ctx.LockKeyboardCapture = false
+		return nil
+	}
+	var cmd tea.Cmd
+	m.exportPrompt, cmd = m.exportPrompt.Update(msg)
+	return cmd
+}
+
+// e.g. rds-databases.csv in the working directory
+func (m *Model) defaultExportPath() string {
+	name := fmt.Sprintf("%s-%s", m.ctx.AwsService, m.Pane.GetSpec().GetName())
+	name = strings.NewReplacer("/", "-", " ", "-").Replace(strings.ToLower(name))
+	return name + ".csv"
+}
+
+// Writes the marked rows, or else the rows the table is showing so any search applies, to path in
+// the format matching its extension. Reports the outcome with a toast.
+func (m *Model) export(path string) tea.Cmd {
+	columns := make([]string, len(m.Columns))
+	for i, c := range m.Columns {
+		columns[i] = c.Title
+	}
+	var rows []Row
+	if m.HasMarkedRows() {
+		rows = m.MarkedRows()
+	} else {
+		rows = make([]Row, len(m.filtered))
+		for i := range m.filtered {
+			rows[i] = m.GetRowAt(i)
+		}
+	}
+
+	return func() tea.Msg {
+		if err := exportRows(path, columns, rows); err != nil {
+			return toast.ShowMsg{
+				Message: fmt.Sprintf("Export failed: %v", err),
+				IsError: true,
+			}
+		}
+		return toast.ShowMsg{
+			Message: fmt.Sprintf("Wrote %d rows to %s", len(rows), path),
+		}
+	}
+}
+
+func exportRows(path string, columns []string, rows []Row) error {
+	format := ExportFormatForPath(path)
+	if format == "" {
+		return fmt.Errorf("can't tell the format of %q, use a .csv, .json or .md file", path)
+	}
+
+	if strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		path = filepath.Join(homeDir, path[2:])
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteRows(file, format, columns, rows); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,82 @@
+package table
+
+// Marks or unmarks the row under the cursor, then moves down
+func (m *Model) toggleMark() {
+	if len(m.filtered) == 0 {
+		return
+	}
+	id := m.filtered[m.rowsViewport.GetCurrItem()]
+	m.setMarked(id, !m.marked[id])
+	m.marksChanged()
+	m.rowsViewport.NextItem()
+}
+
+// Marks every row the search matches, or unmarks them if they all are already
+func (m *Model) toggleMarkAll() {
+	all := true
+	for _, id := range m.filtered {
+		if !m.marked[id] {
+			all = false
+			break
+		}
+	}
+	for _, id := range m.filtered {
+		m.setMarked(id, !all)
+	}
+	m.marksChanged()
+}
+
+// Flips the marks on the rows the search matches, the rest keep theirs
+func (m *Model) invertMarks() {
+	for _, id := range m.filtered {
+		m.setMarked(id, !m.marked[id])
+	}
+	m.marksChanged()
+}
+
+func (m *Model) setMarked(id int, marked bool) {
+	if marked {
+		m.marked[id] = true
+	} else {
+		delete(m.marked, id)
+	}
+}
+
+// Redraws the rows and counts the marks, once however many rows were marked
+func (m *Model) marksChanged() {
+	m.invalidateRenderedRows()
+	m.updateFootnote()
+}
+
+func (m *Model) HasMarkedRows() bool {
+	return len(m.marked) > 0
+}
+
+// The marked rows, in the order they're shown. Rows the search hides stay marked, and come last.
+func (m *Model) MarkedRows() []Row {
+	rows := make([]Row, 0, len(m.marked))
+	seen := make(map[int]bool, len(m.marked))
+	for _, id := range m.filtered {
+		if m.marked[id] {
+			rows = append(rows, m.rows[id])
+			seen[id] = true
+		}
+	}
+	for id, r := range m.rows {
+		if m.marked[id] && !seen[id] {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// The marked rows, or the one under the cursor if none are. What actions on rows apply to.
+func (m *Model) GetActionRows() []Row {
+	if m.HasMarkedRows() {
+		return m.MarkedRows()
+	}
+	if row := m.GetCurrentRow(); row != nil {
+		return []Row{row}
+	}
+	return nil
+}
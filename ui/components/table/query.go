@@ -0,0 +1,563 @@
+package table
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/danielcmessias/sawsy/utils"
+)
+
+// A parsed table search. Terms are separated by spaces and all have to match, unless joined by OR:
+//
+//	prod                 any cell containing prod, ignoring case
+//	"eu west"            quoted to include spaces
+//	Name:prod            only looking in the Name column
+//	/^prod-\d+$/         a regular expression
+//	~pdb                 fuzzy, the letters in order but not necessarily next to each other
+//	Size>1000000         compared by value, also >=, <, <=, = and !=, sizes like 1MB work too
+//	-prod, !prod         negated, as is NOT prod
+//	a OR b, a | b        either, grouped with parentheses: (a OR b) c
+type Query struct {
+	root queryNode
+}
+
+// Queries look at the text cells are shown as, except comparisons which look at their values
+type queryNode interface {
+	matches(row Row, text []string) bool
+	// Adds the spans of the text in column col matched by the query, as start and end offsets
+	highlight(row Row, text []string, col int, add func(start int, end int))
+}
+
+// Parses a search, columns being the ones its terms can be limited to by title
+func ParseQuery(text string, columns []Column) (*Query, error) {
+	tokens, err := lexQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := queryParser{tokens: tokens, columns: columns}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %s", p.peek().text)
+	}
+	return &Query{root: root}, nil
+}
+
+// Whether the row, shown as text, should be shown. A nil query, from an empty search, matches
+// everything.
+func (q *Query) Matches(row Row, text []string) bool {
+	return q == nil || q.root.matches(row, text)
+}
+
+// The spans of a cell's text matched by the query, ordered and without overlaps
+func (q *Query) Highlights(row Row, text []string, col int) [][2]int {
+	if q == nil {
+		return nil
+	}
+	var spans [][2]int
+	q.root.highlight(row, text, col, func(start int, end int) {
+		spans = append(spans, [2]int{start, end})
+	})
+	return mergeSpans(spans)
+}
+
+type andNode []queryNode
+
+func (n andNode) matches(row Row, text []string) bool {
+	for _, q := range n {
+		if !q.matches(row, text) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n andNode) highlight(row Row, text []string, col int, add func(int, int)) {
+	for _, q := range n {
+		q.highlight(row, text, col, add)
+	}
+}
+
+type orNode []queryNode
+
+func (n orNode) matches(row Row, text []string) bool {
+	for _, q := range n {
+		if q.matches(row, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n orNode) highlight(row Row, text []string, col int, add func(int, int)) {
+	for _, q := range n {
+		if q.matches(row, text) {
+			q.highlight(row, text, col, add)
+		}
+	}
+}
+
+type notNode struct {
+	q queryNode
+}
+
+func (n notNode) matches(row Row, text []string) bool {
+	return !n.q.matches(row, text)
+}
+
+// There's nothing in a cell to point at for something that isn't there
+func (n notNode) highlight(row Row, text []string, col int, add func(int, int)) {}
+
+// A single term, looking in one column or, if column is -1, all of them
+type termNode struct {
+	column int
+	match  cellMatcher
+}
+
+type cellMatcher interface {
+	// The spans of the cell's text matched, empty if it doesn't match. A match without anything to
+	// point at returns a single empty span.
+	find(cell Cell, text string) [][2]int
+}
+
+func (n termNode) matches(row Row, text []string) bool {
+	for i := range text {
+		if (n.column == -1 || n.column == i) && i < len(row) && len(n.match.find(row[i], text[i])) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (n termNode) highlight(row Row, text []string, col int, add func(int, int)) {
+	if (n.column != -1 && n.column != col) || col >= len(row) || col >= len(text) {
+		return
+	}
+	for _, span := range n.match.find(row[col], text[col]) {
+		if span[0] < span[1] {
+			add(span[0], span[1])
+		}
+	}
+}
+
+type substringMatcher struct {
+	needle string // Lowercase
+}
+
+func (m substringMatcher) find(_ Cell, cell string) [][2]int {
+	// Lowering can change the length of some characters, in which case there's nothing to point at
+	lower := strings.ToLower(cell)
+	if len(lower) != len(cell) {
+		if strings.Contains(lower, m.needle) {
+			return [][2]int{{0, 0}}
+		}
+		return nil
+	}
+
+	var spans [][2]int
+	for start := 0; ; {
+		i := strings.Index(lower[start:], m.needle)
+		if i == -1 || m.needle == "" {
+			break
+		}
+		spans = append(spans, [2]int{start + i, start + i + len(m.needle)})
+		start += i + len(m.needle)
+	}
+	return spans
+}
+
+type regexMatcher struct {
+	re *regexp.Regexp
+}
+
+func (m regexMatcher) find(_ Cell, cell string) [][2]int {
+	var spans [][2]int
+	for _, loc := range m.re.FindAllStringIndex(cell, -1) {
+		spans = append(spans, [2]int{loc[0], loc[1]})
+	}
+	// Matching nothing, e.g. with /^/, still matches
+	if spans == nil && m.re.MatchString(cell) {
+		spans = [][2]int{{0, 0}}
+	}
+	return spans
+}
+
+type fuzzyMatcher struct {
+	query string
+}
+
+func (m fuzzyMatcher) find(_ Cell, cell string) [][2]int {
+	_, positions, ok := utils.FuzzyMatch(m.query, cell)
+	if !ok {
+		return nil
+	}
+	spans := [][2]int{{0, 0}}
+	for _, p := range positions {
+		_, size := utf8.DecodeRuneInString(cell[p:])
+		spans = append(spans, [2]int{p, p + size})
+	}
+	return spans
+}
+
+type comparisonMatcher struct {
+	op    string
+	value sortKey
+}
+
+func (m comparisonMatcher) find(cell Cell, text string) [][2]int {
+	key := cellSortKey(cell, text)
+	if key.kind == blankCell {
+		return nil
+	}
+
+	var cmp int
+	switch {
+	case key.kind != textCell && m.value.kind != textCell:
+		cmp = compareFloats(key.value, m.value.value)
+	case m.op == "=" || m.op == "!=":
+		cmp = strings.Compare(strings.ToLower(key.text), strings.ToLower(m.value.text))
+	default:
+		// Ordering text against a number means nothing
+		return nil
+	}
+
+	var ok bool
+	switch m.op {
+	case ">":
+		ok = cmp > 0
+	case ">=":
+		ok = cmp >= 0
+	case "<":
+		ok = cmp < 0
+	case "<=":
+		ok = cmp <= 0
+	case "=":
+		ok = cmp == 0
+	case "!=":
+		ok = cmp != 0
+	}
+	if !ok {
+		return nil
+	}
+	return [][2]int{{0, len(text)}}
+}
+
+func compareFloats(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+type tokenType int
+
+const (
+	termToken tokenType = iota
+	andToken
+	orToken
+	notToken
+	openToken
+	closeToken
+)
+
+type queryToken struct {
+	typ  tokenType
+	text string
+}
+
+// Splits a search into terms and operators. Quotes and regular expressions are kept as they are in
+// terms, to be picked apart by parseTerm.
+func lexQuery(text string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t':
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, queryToken{openToken, "("})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, queryToken{closeToken, ")"})
+			i++
+			continue
+		case r == '|' || r == '&':
+			typ := orToken
+			if r == '&' {
+				typ = andToken
+			}
+			// || and && too
+			for i < len(runes) && runes[i] == r {
+				i++
+			}
+			tokens = append(tokens, queryToken{typ, string(r)})
+			continue
+		case (r == '-' || r == '!') && i+1 < len(runes) && runes[i+1] != ' ' && runes[i+1] != '=':
+			tokens = append(tokens, queryToken{notToken, string(r)})
+			i++
+			continue
+		}
+
+		start := i
+		valueStart := true // Whether a regular expression could start here
+		for i < len(runes) && !strings.ContainsRune(" \t()", runes[i]) {
+			switch {
+			case runes[i] == '"':
+				end := indexRune(runes, '"', i+1)
+				if end == -1 {
+					return nil, fmt.Errorf("missing closing quote")
+				}
+				i = end + 1
+				valueStart = false
+				continue
+			case runes[i] == '/' && valueStart:
+				end := indexRune(runes, '/', i+1)
+				if end == -1 {
+					return nil, fmt.Errorf("missing closing / of regular expression")
+				}
+				i = end + 1
+				valueStart = false
+				continue
+			}
+			valueStart = strings.ContainsRune(":<>=", runes[i])
+			i++
+		}
+		term := string(runes[start:i])
+
+		switch term {
+		case "AND":
+			tokens = append(tokens, queryToken{andToken, term})
+		case "OR":
+			tokens = append(tokens, queryToken{orToken, term})
+		case "NOT":
+			tokens = append(tokens, queryToken{notToken, term})
+		default:
+			tokens = append(tokens, queryToken{termToken, term})
+		}
+	}
+	return tokens, nil
+}
+
+// The index of the first r in runes from start, skipping any escaped with a backslash
+func indexRune(runes []rune, r rune, start int) int {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == '\\' {
+			i++
+			continue
+		}
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+type queryParser struct {
+	tokens  []queryToken
+	pos     int
+	columns []Column
+}
+
+func (p *queryParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	var nodes orNode
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		if p.done() || p.peek().typ != orToken {
+			break
+		}
+		p.pos++
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+// Terms next to each other are ANDed, saying so is optional
+func (p *queryParser) parseAnd() (queryNode, error) {
+	var nodes andNode
+	for {
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		if p.done() || p.peek().typ == orToken || p.peek().typ == closeToken {
+			break
+		}
+		if p.peek().typ == andToken {
+			p.pos++
+		}
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *queryParser) parseNot() (queryNode, error) {
+	if !p.done() && p.peek().typ == notToken {
+		p.pos++
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node}, nil
+	}
+	return p.parseAtom()
+}
+
+func (p *queryParser) parseAtom() (queryNode, error) {
+	if p.done() {
+		return nil, fmt.Errorf("missing a term at the end")
+	}
+
+	token := p.peek()
+	p.pos++
+	switch token.typ {
+	case openToken:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().typ != closeToken {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	case termToken:
+		return p.parseTerm(token.text)
+	}
+	return nil, fmt.Errorf("unexpected %s", token.text)
+}
+
+var comparisonOps = []string{">=", "<=", "!=", ">", "<", "="}
+
+// Parses [column:]value or column<op>value
+func (p *queryParser) parseTerm(term string) (queryNode, error) {
+	column, op, value := splitTerm(term)
+
+	if op == "" {
+		return p.newTerm(-1, term, value)
+	}
+
+	id := p.columnId(unquote(column))
+	if op == ":" {
+		// Not a column, so probably something with a colon in it, like an ARN
+		if id == -1 {
+			return p.newTerm(-1, term, term)
+		}
+		return p.newTerm(id, term, value)
+	}
+
+	if id == -1 {
+		return nil, fmt.Errorf("no column named %s", unquote(column))
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing a value to compare %s to", unquote(column))
+	}
+	return termNode{column: id, match: comparisonMatcher{op: op, value: parseSortKey(unquote(value))}}, nil
+}
+
+func (p *queryParser) newTerm(column int, term string, value string) (queryNode, error) {
+	switch {
+	case value == "":
+		return nil, fmt.Errorf("missing a value in %s", term)
+	case len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/"):
+		re, err := regexp.Compile(value[1 : len(value)-1])
+		if err != nil {
+			return nil, fmt.Errorf("bad regular expression %s", value)
+		}
+		return termNode{column: column, match: regexMatcher{re}}, nil
+	case strings.HasPrefix(value, "~") && len(value) > 1:
+		return termNode{column: column, match: fuzzyMatcher{unquote(value[1:])}}, nil
+	}
+	return termNode{column: column, match: substringMatcher{strings.ToLower(unquote(value))}}, nil
+}
+
+// Splits a term at the first colon or comparison outside of quotes and regular expressions
+func splitTerm(term string) (string, string, string) {
+	if strings.HasPrefix(term, "/") {
+		return "", "", term
+	}
+	inQuote := false
+	for i, r := range term {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case r == ':':
+			return term[:i], ":", term[i+1:]
+		case strings.ContainsRune("<>=!", r):
+			for _, op := range comparisonOps {
+				if strings.HasPrefix(term[i:], op) {
+					return term[:i], op, term[i+len(op):]
+				}
+			}
+		}
+	}
+	return "", "", term
+}
+
+func (p *queryParser) columnId(title string) int {
+	for i, c := range p.columns {
+		if strings.EqualFold(c.Title, title) {
+			return i
+		}
+	}
+	return -1
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return strings.ReplaceAll(s[1:len(s)-1], `\"`, `"`)
+	}
+	return s
+}
+
+// Sorts spans and joins those that overlap or touch
+func mergeSpans(spans [][2]int) [][2]int {
+	if len(spans) < 2 {
+		return spans
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i][0] < spans[j][0]
+	})
+	merged := [][2]int{spans[0]}
+	for _, s := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if s[0] <= last[1] {
+			last[1] = utils.Max(last[1], s[1])
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
@@ -0,0 +1,154 @@
+package table
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var queryColumns = []Column{{Title: "Name"}, {Title: "Region"}, {Title: "Size"}, {Title: "Created"}, {Title: "Duration"}}
+
+var queryRows = []Row{
+	{"prod-api", "eu-west-1", Bytes(2_000_000), time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), 90 * time.Second},
+	{"prod-db", "us-east-1", Bytes(500), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 3 * time.Hour},
+	{"dev api", "eu-west-2", Bytes(0), time.Time{}, time.Minute},
+	{"staging", "eu west", Bytes(1 << 20), time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC), 2 * time.Hour},
+}
+
+// The names of the rows q matches
+func queryMatches(t *testing.T, q string) ([]string, error) {
+	t.Helper()
+	query, err := ParseQuery(q, queryColumns)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, row := range queryRows {
+		if query.Matches(row, DefaultCellFormat.FormatRow(row)) {
+			names = append(names, row[0].(string))
+		}
+	}
+	return names, nil
+}
+
+func TestQueryMatches(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"prod-api", "prod-db", "dev api", "staging"}},
+		{"PROD", []string{"prod-api", "prod-db"}},
+		{"prod api", []string{"prod-api"}},
+		{"prod AND api", []string{"prod-api"}},
+		{"prod && api", []string{"prod-api"}},
+
+		// AND binds tighter than OR
+		{"staging OR prod db", []string{"prod-db", "staging"}},
+		{"staging | prod db", []string{"prod-db", "staging"}},
+		{"(staging OR prod) db", []string{"prod-db"}},
+		{"prod db OR staging", []string{"prod-db", "staging"}},
+
+		{"NOT prod", []string{"dev api", "staging"}},
+		{"-prod", []string{"dev api", "staging"}},
+		{"!prod", []string{"dev api", "staging"}},
+		{"NOT (prod OR staging)", []string{"dev api"}},
+		{"NOT NOT staging", []string{"staging"}},
+
+		{`"dev api"`, []string{"dev api"}},
+		{`"eu west"`, []string{"staging"}},
+		{`Name:"dev api"`, []string{"dev api"}},
+		{"name:api", []string{"prod-api", "dev api"}},
+		{"Region:west-1", []string{"prod-api"}},
+		// Not a column, so the whole term is looked for
+		{"Nope:prod", nil},
+
+		{`/^prod-(api|db)$/`, []string{"prod-api", "prod-db"}},
+		{`Region:/^eu-/`, []string{"prod-api", "dev api"}},
+		{`Name:/a.*i$/`, []string{"prod-api", "dev api"}},
+
+		{"~pdb", []string{"prod-db"}},
+		{"~stg", []string{"staging"}},
+
+		{"Region=EU-WEST-1", []string{"prod-api"}},
+		{"Region!=eu-west-1", []string{"prod-db", "dev api", "staging"}},
+		{`Region="eu west"`, []string{"staging"}},
+
+		// Typed cells are compared by value
+		{"Size>1MB", []string{"prod-api", "staging"}},
+		{"Size>1MiB", []string{"prod-api"}},
+		{"Size>=1MiB", []string{"prod-api", "staging"}},
+		{"Size<=500", []string{"prod-db", "dev api"}},
+		{"Size=0", []string{"dev api"}},
+		{"Duration<1h", []string{"prod-api", "dev api"}},
+		{"Duration>=2h", []string{"prod-db", "staging"}},
+		{"Duration>90s", []string{"prod-db", "staging"}},
+		{"Created>2022-01-01T00:00:00Z", []string{"prod-api", "staging"}},
+		{`Created<"01/06/2022 00:00:00"`, []string{"prod-db", "staging"}},
+		// Zero times are blank, and match no comparison
+		{"Created<2030-01-01T00:00:00Z", []string{"prod-api", "prod-db", "staging"}},
+		// Ordering text against a number means nothing
+		{"Name>5", nil},
+	}
+	for _, tt := range tests {
+		got, err := queryMatches(t, tt.query)
+		if err != nil {
+			t.Errorf("%q: %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q matched %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{`"dev api`, "missing closing quote"},
+		{"/prod", "missing closing /"},
+		{"/(/", "bad regular expression"},
+		{"Name:/[a-/", "bad regular expression"},
+		{"Nope>1", "no column named Nope"},
+		{`"No pe"<1`, "no column named No pe"},
+		{"Size>", "missing a value to compare Size"},
+		{"(prod", "missing closing parenthesis"},
+		{"prod)", "unexpected )"},
+		{"prod OR", "missing a term at the end"},
+		{"NOT", "missing a term at the end"},
+		{"Name:", "missing a value"},
+	}
+	for _, tt := range tests {
+		_, err := queryMatches(t, tt.query)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%q: got error %v, want %q", tt.query, err, tt.err)
+		}
+	}
+}
+
+func TestQueryHighlights(t *testing.T) {
+	row := Row{"prod-api-prod", "eu-west-1"}
+	text := DefaultCellFormat.FormatRow(row)
+	tests := []struct {
+		query string
+		col   int
+		want  [][2]int
+	}{
+		{"prod", 0, [][2]int{{0, 4}, {9, 13}}},
+		{"Region:prod", 0, nil},
+		{"prod-a api", 0, [][2]int{{0, 8}}},
+		{"-eu prod", 1, nil},
+		{"/d-a/", 0, [][2]int{{3, 6}}},
+	}
+	for _, tt := range tests {
+		query, err := ParseQuery(tt.query, queryColumns)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.query, err)
+		}
+		if got := query.Highlights(row, text, tt.col); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q highlighted %v in column %d, want %v", tt.query, got, tt.col, tt.want)
+		}
+	}
+}
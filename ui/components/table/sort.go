@@ -0,0 +1,213 @@
+package table
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How times are shown in cells unless the config says otherwise, e.g. 31/12/2022 23:59:59
+const TIME_FORMAT = "02/01/2006 15:04:05"
+
+// Shown by the data package in cells still being fetched
+const loadingCell = "..."
+
+type sortOrder int
+
+const (
+	unsorted sortOrder = iota
+	ascending
+	descending
+)
+
+// What a cell was understood as, cells are only compared by value when they're the same kind
+type cellKind int
+
+const (
+	textCell cellKind = iota
+	numberCell
+	bytesCell
+	durationCell
+	timeCell
+	// Empty or still loading, always sorted last
+	blankCell
+)
+
+type sortKey struct {
+	kind  cellKind
+	value float64
+	text  string
+}
+
+var byteUnits = map[string]float64{
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"KIB": 1 << 10,
+	"MIB": 1 << 20,
+	"GIB": 1 << 30,
+	"TIB": 1 << 40,
+}
+
+// Sorts by the selected column, first ascending, then descending, then back to the order the
+// rows arrived in
+func (m *Model) toggleSort() {
+	switch {
+	case m.sortColumnId != m.currColumnId || m.sortOrder == unsorted:
+		m.sortColumnId = m.currColumnId
+		m.sortOrder = ascending
+	case m.sortOrder == ascending:
+		m.sortOrder = descending
+	default:
+		m.sortOrder = unsorted
+	}
+	m.filterRows()
+}
+
+func (m *Model) sortRows() {
+	if m.sortOrder == unsorted {
+		return
+	}
+	m.sortIds(m.filtered)
+}
+
+// Sorts ids of rows in place, those that sort the same stay in the order they were in
+func (m *Model) sortIds(ids []int) {
+	// Parse each cell once rather than on every comparison
+	type keyedRow struct {
+		id  int
+		key sortKey
+	}
+	keyed := make([]keyedRow, len(ids))
+	for i, id := range ids {
+		keyed[i] = keyedRow{id: id, key: m.rowSortKey(id)}
+	}
+
+	sort.SliceStable(keyed, func(i, j int) bool {
+		return m.sortsBefore(keyed[i].key, keyed[j].key)
+	})
+
+	for i, k := range keyed {
+		ids[i] = k.id
+	}
+}
+
+// Puts rows just appended into place among the filtered rows, which are already sorted. Only the
+// new rows are sorted, and each is placed with a binary search, so appending a page to a long
+// table costs little more than the page.
+func (m *Model) insertSorted(ids []int) {
+	m.sortIds(ids)
+
+	merged := make([]int, 0, len(m.filtered)+len(ids))
+	start := 0
+	for _, id := range ids {
+		key := m.rowSortKey(id)
+		// After any equal rows, as they arrived first
+		at := start + sort.Search(len(m.filtered)-start, func(i int) bool {
+			return m.sortsBefore(key, m.rowSortKey(m.filtered[start+i]))
+		})
+		merged = append(append(merged, m.filtered[start:at]...), id)
+		start = at
+	}
+	m.filtered = append(merged, m.filtered[start:]...)
+}
+
+// Rows short of cells have blanks in the missing columns
+func (m *Model) rowSortKey(id int) sortKey {
+	return cellSortKey(cellAt(m.rows[id], m.sortColumnId), textAt(m.texts[id], m.sortColumnId))
+}
+
+// Blanks go last whichever way the rows are sorted
+func (m *Model) sortsBefore(a sortKey, b sortKey) bool {
+	if a.kind == blankCell || b.kind == blankCell {
+		return b.kind == blankCell && a.kind != blankCell
+	}
+	if m.sortOrder == descending {
+		a, b = b, a
+	}
+	return lessSortKey(a, b)
+}
+
+// Cells of different kinds, e.g. a "-" among numbers, are grouped by kind
+func lessSortKey(a sortKey, b sortKey) bool {
+	if a.kind != b.kind {
+		return a.kind < b.kind
+	}
+	if a.kind == textCell {
+		return strings.ToLower(a.text) < strings.ToLower(b.text)
+	}
+	return a.value < b.value
+}
+
+// Sizes, times, durations and numbers are compared by value, anything else by its text
+func cellSortKey(cell Cell, text string) sortKey {
+	key := sortKey{text: text}
+	switch v := cell.(type) {
+	case Bytes:
+		key.kind, key.value = bytesCell, float64(v)
+	case time.Time:
+		if v.IsZero() {
+			key.kind = blankCell
+		} else {
+			key.kind, key.value = timeCell, float64(v.UnixNano())
+		}
+	case time.Duration:
+		key.kind, key.value = durationCell, float64(v)
+	default:
+		if n, ok := numberValue(cell); ok {
+			key.kind, key.value = numberCell, n
+		} else {
+			return parseSortKey(text)
+		}
+	}
+	return key
+}
+
+// Understands numbers, byte sizes, durations like 3m 12s and times written out as text, as in
+// text cells and searches
+func parseSortKey(cell string) sortKey {
+	text := strings.TrimSpace(cell)
+	key := sortKey{kind: textCell, text: text}
+	if text == "" || text == loadingCell {
+		key.kind = blankCell
+		return key
+	}
+
+	if n, err := strconv.ParseFloat(strings.TrimSuffix(strings.ReplaceAll(text, ",", ""), "%"), 64); err == nil {
+		key.kind, key.value = numberCell, n
+	} else if n, ok := parseBytes(text); ok {
+		key.kind, key.value = bytesCell, n
+	} else if d, err := time.ParseDuration(strings.ReplaceAll(text, " ", "")); err == nil {
+		key.kind, key.value = durationCell, float64(d)
+	} else if t, ok := parseTime(text); ok {
+		key.kind, key.value = timeCell, float64(t.UnixNano())
+	}
+	return key
+}
+
+func parseBytes(s string) (float64, bool) {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i <= 0 {
+		return 0, false
+	}
+	unit, ok := byteUnits[strings.ToUpper(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	return n * unit, err == nil
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range []string{TIME_FORMAT, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
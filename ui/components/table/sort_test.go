@@ -0,0 +1,84 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/danielcmessias/sawsy/ui/components/pane"
+	"github.com/danielcmessias/sawsy/ui/context"
+	"github.com/danielcmessias/sawsy/utils"
+)
+
+func newTestTable(titles ...string) *Model {
+	var columns []Column
+	for _, t := range titles {
+		columns = append(columns, Column{Title: t})
+	}
+	m := New(&context.ProgramContext{Keys: utils.DefaultKeys}, TableSpec{
+		BaseSpec: pane.BaseSpec{Name: "Test"},
+		Columns:  columns,
+	})
+	m.SetSize(120, 40)
+	return m
+}
+
+func shownNames(m *Model) []string {
+	var names []string
+	for _, id := range m.filtered {
+		names = append(names, RawText(m.rows[id][0]))
+	}
+	return names
+}
+
+func TestSortShortRows(t *testing.T) {
+	m := newTestTable("Name", "Size")
+	m.AppendRows([]Row{
+		{"big", Bytes(2048)},
+		{"loading"},
+		{"small", Bytes(10)},
+	})
+	m.currColumnId = 1
+
+	m.toggleSort()
+	want := []string{"small", "big", "loading"}
+	if got := shownNames(m); !reflect.DeepEqual(got, want) {
+		t.Errorf("ascending = %v, want %v", got, want)
+	}
+
+	// Blanks stay last either way
+	m.toggleSort()
+	want = []string{"big", "small", "loading"}
+	if got := shownNames(m); !reflect.DeepEqual(got, want) {
+		t.Errorf("descending = %v, want %v", got, want)
+	}
+}
+
+func TestAppendSorted(t *testing.T) {
+	pages := [][]Row{
+		{{"c", Bytes(30)}, {"a", Bytes(10)}, {"loading"}},
+		{{"b", Bytes(20)}, {"a2", Bytes(10)}, {"e", Bytes(50)}},
+		{{"d", Bytes(40)}, {"loading2"}, {"a3", Bytes(10)}},
+	}
+	for _, toggles := range []int{1, 2} {
+		appended := newTestTable("Name", "Size")
+		appended.currColumnId = 1
+		all := newTestTable("Name", "Size")
+		all.currColumnId = 1
+		for i := 0; i < toggles; i++ {
+			appended.toggleSort()
+		}
+
+		for _, rows := range pages {
+			appended.AppendRows(rows)
+			all.AppendRows(rows)
+		}
+		for i := 0; i < toggles; i++ {
+			all.toggleSort()
+		}
+
+		// The same as sorting everything at once, equal sizes in the order they arrived
+		if got, want := shownNames(appended), shownNames(all); !reflect.DeepEqual(got, want) {
+			t.Errorf("sorted %d times, appended = %v, want %v", toggles, got, want)
+		}
+	}
+}
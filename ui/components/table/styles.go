@@ -7,36 +7,66 @@ import (
 
 var (
 	headerHeight = 3
+
+	sortIndicators = map[sortOrder]string{
+		ascending:  " ↑",
+		descending: " ↓",
+	}
 	searchHeight = 1
 
 	cellStyle = lipgloss.NewStyle().
-			Foreground(styles.Theme.MainText).
 			PaddingLeft(1).
 			PaddingRight(1).
 			MaxHeight(1)
 
-	selectedCellStyle = cellStyle.Copy().
-				Bold(true).
-				Foreground(styles.Theme.HighlightRow)
+	selectedCellStyle      lipgloss.Style
+	markedRowColor         lipgloss.AdaptiveColor
+	titleCellStyle         lipgloss.Style
+	selectedTitleCellStyle lipgloss.Style
+	headerStyle            lipgloss.Style
+	rowStyle               lipgloss.Style
+	promptStyle            lipgloss.Style
+	matchStyle             lipgloss.Style
+	queryErrorStyle        lipgloss.Style
+)
+
+func init() {
+	styles.OnThemeChange(func() {
+		cellStyle = cellStyle.Foreground(styles.Theme.MainText)
+
+		selectedCellStyle = cellStyle.Copy().
+			Bold(true).
+			Foreground(styles.Theme.HighlightRow)
+
+		markedRowColor = styles.Theme.PageMetaText
 
-	titleCellStyle = cellStyle.Copy().
+		titleCellStyle = cellStyle.Copy().
 			Bold(true).
 			Foreground(styles.Theme.MainText)
 
-	selectedTitleCellStyle = cellStyle.Copy().
-				Bold(true).
-				Foreground(styles.Theme.HighlightColumn)
+		selectedTitleCellStyle = cellStyle.Copy().
+			Bold(true).
+			Foreground(styles.Theme.HighlightColumn)
 
-	headerStyle = lipgloss.NewStyle().
+		headerStyle = lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(styles.Theme.Border).
 			BorderBottom(true)
 
-	rowStyle = lipgloss.NewStyle().
+		rowStyle = lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(styles.Theme.FaintBorder).
 			BorderBottom(true)
 
-	promptStyle = lipgloss.NewStyle().
+		promptStyle = lipgloss.NewStyle().
 			Foreground(styles.Theme.SearchPrompt)
-)
+
+		matchStyle = lipgloss.NewStyle().
+			Foreground(styles.Theme.SearchPrompt).
+			Underline(true)
+
+		queryErrorStyle = lipgloss.NewStyle().
+			Foreground(styles.Theme.ErrorText).
+			PaddingLeft(1)
+	})
+}
@@ -1,6 +1,7 @@
 package table
 
 import (
+	"fmt"
 	"log"
 	"strings"
 
@@ -20,26 +21,57 @@ type Model struct {
 
 	ctx          *context.ProgramContext
 	search       textinput.Model
+	exportPrompt textinput.Model
 	Columns      []Column
 	rows         []Row
-	filteredRows []Row
+	texts        [][]string // The rows' cells as they're shown
+	format       CellFormat
+	filtered     []int // Of the rows matching the search, in the order shown
 	filterText   string
+	query        *Query
+	queryErr     error // From the search as typed, while rows are still filtered by the last query that parsed
 	rowsViewport listviewport.Model
 	width        int
 	height       int
 	currColumnId int
 	colMaxWidths []int
 	noDataLabel  string
+
+	// Where the column layout is kept in the config, and the columns in view
+	layoutKey string
+	colOffset int // Of the first column in view, among the visible ones
+	shown     []shownColumn
+
+	marked map[int]bool // By index in rows
+
+	// Rows already rendered, by their index in filtered, for as long as the columns shown stay
+	// the same. The selected row is never kept, it's drawn differently.
+	renderedRows map[int]string
+
+	// Continuation token for the next page of rows, nil once everything has been loaded
+	nextToken        *string
+	fetchingNextPage bool
+
+	// The rows came from the response cache and fresh ones are on their way
+	stale bool
+
+	sortColumnId int
+	sortOrder    sortOrder
 }
 
 type Column struct {
 	Title string
-	// MaxWidth is unused for now
-	MaxWidth   *int
-	isSelected *bool
+	// Longer cells are cut short
+	MaxWidth *int
 }
 
-type Row []string
+type Row []Cell
+
+// Sent when the table wants its next page of rows, e.g. because the cursor is near the end
+type LoadMoreMsg struct {
+	Table     *Model
+	NextToken *string
+}
 
 type TableSpec struct {
 	pane.BaseSpec
@@ -59,30 +91,43 @@ func New(ctx *context.ProgramContext, spec TableSpec) *Model {
 	columns := make([]Column, len(spec.Columns))
 	copy(columns, spec.Columns)
 
-	columns[0].isSelected = utils.BoolPtr(true)
-
 	search := textinput.New()
 	search.Prompt = "Search: "
 	search.Placeholder = "..."
 	search.PromptStyle = promptStyle
 	search.Width = 40
 
+	exportPrompt := textinput.New()
+	exportPrompt.Prompt = "Export to: "
+	exportPrompt.PromptStyle = promptStyle
+	exportPrompt.Width = 60
+
 	colMaxWidths := make([]int, len(columns))
 	// Set the max width default to the length of the column header
 	for i, c := range columns {
 		colMaxWidths[i] = lipgloss.Width(titleCellStyle.Render(c.Title))
 	}
 
+	// A time zone that doesn't exist is reported as sawsy starts
+	format := DefaultCellFormat
+	if ctx.Config != nil {
+		format, _ = NewCellFormat(ctx.Config.Format)
+	}
+
 	return &Model{
 		Pane: pane.New(spec.BaseSpec),
 
 		ctx:          ctx,
 		search:       search,
+		exportPrompt: exportPrompt,
 		Columns:      columns,
 		filterText:   "",
 		rowsViewport: listviewport.NewModel(spec.BaseSpec.Name, 0, 2),
 		colMaxWidths: colMaxWidths,
 		noDataLabel:  "Loading...",
+		format:       format,
+		renderedRows: map[int]string{},
+		marked:       map[int]bool{},
 	}
 }
 
@@ -94,6 +139,15 @@ func (m *Model) Update(msg tea.Msg) (pane.Pane, tea.Cmd, bool) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	// The prompt has the keyboard to itself while it's open
+	if m.exportPrompt.Focused() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			return m, m.updateExportPrompt(msg), true
+		}
+		m.exportPrompt, cmd = m.exportPrompt.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	m.search, cmd = m.search.Update(msg)
 	cmds = append(cmds, cmd)
 	m.filter(m.search.Value())
@@ -103,22 +157,53 @@ func (m *Model) Update(msg tea.Msg) (pane.Pane, tea.Cmd, bool) {
 		switch {
 		case key.Matches(msg, m.ctx.Keys.Down):
 			m.rowsViewport.NextItem()
+			cmds = append(cmds, m.loadMoreIfNearEnd())
 		case key.Matches(msg, m.ctx.Keys.Up):
 			m.rowsViewport.PrevItem()
 		case key.Matches(msg, m.ctx.Keys.FirstLine):
 			m.rowsViewport.FirstItem()
 		case key.Matches(msg, m.ctx.Keys.LastLine):
 			m.rowsViewport.LastItem()
+			cmds = append(cmds, m.loadMoreIfNearEnd())
+		case key.Matches(msg, m.ctx.Keys.LoadMore) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.loadMore())
 		case key.Matches(msg, m.ctx.Keys.NextCol):
 			m.nextCol()
 		case key.Matches(msg, m.ctx.Keys.PrevCol):
 			m.prevCol()
+		case key.Matches(msg, m.ctx.Keys.MoveColLeft) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.moveCol(-1))
+		case key.Matches(msg, m.ctx.Keys.MoveColRight) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.moveCol(1))
+		case key.Matches(msg, m.ctx.Keys.HideCol) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.hideCol())
+		case key.Matches(msg, m.ctx.Keys.ResetCols) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.resetCols())
 		case key.Matches(msg, m.ctx.Keys.StartSearch):
 			m.search.Focus()
 			m.ctx.LockKeyboardCapture = true
-		case key.Matches(msg, m.ctx.Keys.EndSearch):
+		case key.Matches(msg, m.ctx.Keys.EndSearch) && m.search.Focused():
 			m.search.Blur()
 			m.ctx.LockKeyboardCapture = false
+			m.syncViewPortContent()
+			// Enter also inspects the row, which shouldn't happen on the way out of a search
+			return m, tea.Batch(cmds...), true
+		case key.Matches(msg, m.ctx.Keys.Mark) && !m.ctx.LockKeyboardCapture:
+			m.toggleMark()
+		case key.Matches(msg, m.ctx.Keys.MarkAll) && !m.ctx.LockKeyboardCapture:
+			m.toggleMarkAll()
+		case key.Matches(msg, m.ctx.Keys.InvertMarks) && !m.ctx.LockKeyboardCapture:
+			m.invertMarks()
+		case key.Matches(msg, m.ctx.Keys.Sort) && !m.ctx.LockKeyboardCapture:
+			m.toggleSort()
+		case key.Matches(msg, m.ctx.Keys.Export) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.StartExport())
+		case key.Matches(msg, m.ctx.Keys.CopyCell) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.copyCell())
+		case key.Matches(msg, m.ctx.Keys.CopyRows) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.copyRowsTSV())
+		case key.Matches(msg, m.ctx.Keys.CopyJSON) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.copyRowsJSON())
 		}
 		m.syncViewPortContent()
 	}
@@ -131,8 +216,13 @@ func (m *Model) View() string {
 	body := m.renderBody()
 
 	var search string
-	if m.search.Focused() || m.search.Value() != "" {
+	if m.exportPrompt.Focused() {
+		search = m.exportPrompt.View()
+	} else if m.search.Focused() || m.search.Value() != "" {
 		search = m.search.View()
+		if m.queryErr != nil {
+			search = lipgloss.JoinVertical(lipgloss.Left, search, queryErrorStyle.Render(m.queryErr.Error()))
+		}
 	}
 
 	return lipgloss.JoinVertical(
@@ -148,7 +238,7 @@ func (m *Model) SetSize(width int, height int) {
 	m.height = height
 	m.rowsViewport.SetSize(
 		width,
-		height-headerHeight-searchHeight,
+		height-headerHeight-m.searchHeight(),
 	)
 	m.syncViewPortContent()
 }
@@ -162,10 +252,10 @@ func (m *Model) GetCurrentItem() int {
 }
 
 func (m *Model) GetCurrentRow() Row {
-	if len(m.filteredRows) == 0 {
+	if len(m.filtered) == 0 {
 		return nil
 	}
-	return m.filteredRows[m.rowsViewport.GetCurrItem()]
+	return m.GetRowAt(m.rowsViewport.GetCurrItem())
 }
 
 func (m *Model) GetCurrentRowMarshalled() map[string]string {
@@ -178,59 +268,119 @@ func (m *Model) MarhsalRow(row Row) map[string]string {
 	}
 	rowMap := make(map[string]string)
 	for i, col := range m.Columns {
-		rowMap[col.Title] = row[i]
+		if i < len(row) {
+			rowMap[col.Title] = RawText(row[i])
+		}
 	}
 	return rowMap
 }
 
-func (m *Model) UnmarshalRow(row map[string]string) Row {
-	rowArr := make(Row, len(m.Columns))
+// The index of the column with title, or -1
+func (m *Model) ColumnIndex(title string) int {
 	for i, col := range m.Columns {
-		rowArr[i] = row[col.Title]
+		if col.Title == title {
+			return i
+		}
 	}
-	return rowArr
+	return -1
 }
 
-func (m *Model) nextCol() int {
-	m.Columns[m.currColumnId].isSelected = utils.BoolPtr(false)
-	m.currColumnId = (m.currColumnId + 1) % len(m.Columns)
-	m.Columns[m.currColumnId].isSelected = utils.BoolPtr(true)
-	return m.currColumnId
+// The title of the selected column and the search as typed, e.g. to be put back with
+// RestoreSelection when sawsy is next started
+func (m *Model) Selection() (column string, search string) {
+	if len(m.Columns) == 0 {
+		return "", m.search.Value()
+	}
+	return m.Columns[m.currColumnId].Title, m.search.Value()
 }
 
-func (m *Model) prevCol() int {
-	m.Columns[m.currColumnId].isSelected = utils.BoolPtr(false)
-	m.currColumnId = (m.currColumnId - 1) % len(m.Columns)
-	if m.currColumnId < 0 {
-		m.currColumnId = len(m.Columns) - 1
+// Selects the column with title, if there still is one, and searches for search
+func (m *Model) RestoreSelection(column string, search string) {
+	if i := m.ColumnIndex(column); i >= 0 {
+		m.currColumnId = i
 	}
-	m.Columns[m.currColumnId].isSelected = utils.BoolPtr(true)
-	return m.currColumnId
+	m.search.SetValue(search)
+	m.filter(search)
 }
 
+// Renders the rows in view, tables can be far too long to render all of
 func (m *Model) syncViewPortContent() {
-	headerColumns := m.renderHeaderColumns()
-	renderedRows := make([]string, 0, len(m.filteredRows))
-	for i := range m.filteredRows {
-		renderedRows = append(renderedRows, m.renderRow(i, headerColumns))
+	shown := m.layoutColumns()
+	if !equalColumns(shown, m.shown) {
+		m.invalidateRenderedRows()
+		m.shown = shown
+	}
+
+	start, end := m.rowsViewport.VisibleRange()
+	renderedRows := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		renderedRows = append(renderedRows, m.renderRow(i))
 	}
 	m.rowsViewport.SyncViewPort(
 		lipgloss.JoinVertical(lipgloss.Left, renderedRows...),
 	)
 }
 
+func (m *Model) invalidateRenderedRows() {
+	m.renderedRows = map[int]string{}
+}
+
+func equalColumns(a []shownColumn, b []shownColumn) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *Model) GetRowAt(index int) Row {
-	return m.filteredRows[index]
+	return m.rows[m.filtered[index]]
 }
 
 func (m *Model) SetRows(rows []Row) {
 	m.rows = rows
+	m.texts = m.formatRows(rows)
+	m.marked = map[int]bool{}
+	m.growColumns(m.texts)
 	m.filterRows()
 }
 
+// Cells are formatted once, as they arrive, rather than each time they're searched or drawn
+func (m *Model) formatRows(rows []Row) [][]string {
+	texts := make([][]string, len(rows))
+	for i, row := range rows {
+		texts[i] = m.format.FormatRow(row)
+	}
+	return texts
+}
+
+// Only the new rows are filtered, and measured, so that loading page after page of a long list
+// doesn't go over the rows already loaded each time
 func (m *Model) AppendRows(rows []Row) {
-	newRows := append(m.rows, rows...)
-	m.SetRows(newRows)
+	texts := m.formatRows(rows)
+	m.rows = append(m.rows, rows...)
+	m.texts = append(m.texts, texts...)
+	m.growColumns(texts)
+	var added []int
+	for i := len(m.rows) - len(rows); i < len(m.rows); i++ {
+		if m.query.Matches(m.rows[i], m.texts[i]) {
+			added = append(added, i)
+		}
+	}
+	if m.sortOrder != unsorted {
+		m.insertSorted(added)
+		// Rows are cached by where they're shown, which the new ones may have moved
+		m.invalidateRenderedRows()
+	} else {
+		m.filtered = append(m.filtered, added...)
+	}
+	m.rowsViewport.SetNumItems(len(m.filtered))
+	m.syncViewPortContent()
+	m.updateFootnote()
 
 	if len(rows) == 0 {
 		m.noDataLabel = "No data"
@@ -239,23 +389,107 @@ func (m *Model) AppendRows(rows []Row) {
 
 // Updates a row using a given column as the primary key
 func (m *Model) UpdateRow(primaryKeyIndex int, newRow Row) {
+	text := m.format.FormatRow(newRow)
 	newRows := make([]Row, 0, len(m.rows))
-	for _, r := range m.rows {
+	for i, r := range m.rows {
 		if r[primaryKeyIndex] == newRow[primaryKeyIndex] {
 			newRows = append(newRows, newRow)
+			m.texts[i] = text
 		} else {
 			newRows = append(newRows, r)
 		}
 	}
-	m.SetRows(newRows)
+	m.rows = newRows
+	m.growColumns([][]string{text})
+	m.filterRows()
 }
 
 func (m *Model) ClearRows() {
 	m.rows = make([]Row, 0)
+	m.texts = make([][]string, 0)
+	m.marked = map[int]bool{}
+	m.nextToken = nil
+	m.fetchingNextPage = false
+	m.stale = false
+	m.updateFootnote()
 	m.filterRows()
 	m.noDataLabel = "Loading..."
 }
 
+// Remembers where to continue from when more rows are needed, nil if there are no more
+func (m *Model) SetNextToken(nextToken *string) {
+	m.nextToken = nextToken
+	m.fetchingNextPage = false
+	m.updateFootnote()
+}
+
+func (m *Model) HasMoreRows() bool {
+	return m.nextToken != nil
+}
+
+// Called if fetching the next page didn't work out, so that it can be asked for again
+func (m *Model) CancelLoadMore() {
+	m.fetchingNextPage = false
+	m.updateFootnote()
+}
+
+func (m *Model) loadMoreIfNearEnd() tea.Cmd {
+	if m.rowsViewport.GetCurrItem() < len(m.filtered)-m.rowsViewport.GetNumRowsPerPage() {
+		return nil
+	}
+	return m.loadMore()
+}
+
+func (m *Model) loadMore() tea.Cmd {
+	if m.nextToken == nil || m.fetchingNextPage {
+		return nil
+	}
+	m.fetchingNextPage = true
+	m.updateFootnote()
+
+	msg := LoadMoreMsg{
+		Table:     m,
+		NextToken: m.nextToken,
+	}
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+func (m *Model) SetStale(stale bool) {
+	m.stale = stale
+	m.updateFootnote()
+}
+
+func (m *Model) IsStale() bool {
+	return m.stale
+}
+
+func (m *Model) updateFootnote() {
+	switch {
+	case m.stale:
+		m.rowsViewport.Footnote = fmt.Sprintf("%d cached, refreshing...", len(m.rows))
+	case m.fetchingNextPage:
+		m.rowsViewport.Footnote = fmt.Sprintf("%d loaded, loading more...", len(m.rows))
+	case m.nextToken != nil:
+		m.rowsViewport.Footnote = fmt.Sprintf(
+			"%d loaded, more available (%s)",
+			len(m.rows),
+			m.ctx.Keys.LoadMore.Help().Key,
+		)
+	default:
+		m.rowsViewport.Footnote = ""
+	}
+
+	if len(m.marked) > 0 {
+		marked := fmt.Sprintf("%d marked", len(m.marked))
+		if m.rowsViewport.Footnote != "" {
+			marked = fmt.Sprintf("%s · %s", marked, m.rowsViewport.Footnote)
+		}
+		m.rowsViewport.Footnote = marked
+	}
+}
+
 func (m *Model) OnLineDown() {
 	m.rowsViewport.NextItem()
 }
@@ -265,93 +499,85 @@ func (m *Model) OnLineUp() {
 }
 
 func (m *Model) filter(filter string) {
+	if filter == m.filterText {
+		return
+	}
 	m.filterText = filter
+
+	hadErr := m.queryErr != nil
+	query, err := ParseQuery(filter, m.Columns)
+	m.queryErr = err
+	if err == nil {
+		m.query = query
+	}
+	// Make room for the error, or take it back
+	if hadErr != (m.queryErr != nil) {
+		m.SetSize(m.width, m.height)
+	}
 	m.filterRows()
 }
 
+// Lines below the rows taken by the search, and any error in it
+func (m *Model) searchHeight() int {
+	if m.queryErr != nil {
+		return searchHeight + 1
+	}
+	return searchHeight
+}
+
 func (m *Model) Hide() {
 	m.search.Blur()
+	if m.exportPrompt.Focused() {
+		m.exportPrompt.Blur()
+		m.ctx.LockKeyboardCapture = false
+	}
 }
 
 func (m *Model) filterRows() {
-	if m.filterText == "" {
-		m.filteredRows = m.rows
-	}
-	filteredRows := make([]Row, 0)
-	for _, r := range m.rows {
-		for _, c := range r {
-			if strings.Contains(c, m.filterText) {
-				filteredRows = append(filteredRows, r)
-				break
-			}
+	filtered := make([]int, 0)
+	for i, r := range m.rows {
+		if m.query.Matches(r, m.texts[i]) {
+			filtered = append(filtered, i)
 		}
 	}
-	m.filteredRows = filteredRows
+	m.filtered = filtered
+	m.sortRows()
+	m.invalidateRenderedRows()
 
-	for _, row := range m.filteredRows {
+	m.rowsViewport.SetNumItems(len(m.filtered))
+	m.syncViewPortContent()
+}
+
+// Widens columns to fit rows. They're never narrowed, so the table doesn't jump around as the
+// rows shown change.
+func (m *Model) growColumns(texts [][]string) {
+	padding := cellStyle.GetHorizontalPadding()
+	for _, text := range texts {
 		for j, col := range m.Columns {
-			w := lipgloss.Width(cellStyle.Copy().Render(row[j]))
-			m.colMaxWidths[j] = utils.Max(m.colMaxWidths[j], w)
+			if j >= len(text) {
+				break
+			}
+			w := lipgloss.Width(text[j]) + padding
 			if col.MaxWidth != nil {
-				m.colMaxWidths[j] = utils.Min(m.colMaxWidths[j], *col.MaxWidth)
+				w = utils.Min(w, *col.MaxWidth)
 			}
+			m.colMaxWidths[j] = utils.Max(m.colMaxWidths[j], w)
 		}
 	}
-
-	m.rowsViewport.SetNumItems(len(m.filteredRows))
-	if m.rowsViewport.GetCurrItem() >= len(m.filteredRows) && len(m.filteredRows) > 0 {
-		m.rowsViewport.LastItem()
-	}
-	m.syncViewPortContent()
 }
 
-func (m *Model) renderHeaderColumns() []string {
-	/* The logic here is basically that in the first pass all columns are assigned the width of
-	 * their title. Then the selected column is allowed to grow as much as possible. Finally, all
-	 * remaining columns (from left to rigth) are allowed to grow as much as possible. All columns
-	 * never grow beyond the largest string in that column, or MaxWidth if set.
-	 *
-	 * This could be improved with proper horizontal scrolling!
-	 */
-
-	renderedColumns := make([]string, len(m.Columns))
-	remainingWidth := m.width
-	var width int
-
-	for i, column := range m.Columns {
-		width = lipgloss.Width(titleCellStyle.Copy().Render(column.Title))
-		if i != m.currColumnId {
-			renderedColumns[i] = titleCellStyle.
-				Copy().
-				Width(width).
-				MaxWidth(width).
-				Render(column.Title)
-			remainingWidth -= width
-		}
-	}
-
-	width = utils.Min(remainingWidth, m.colMaxWidths[m.currColumnId])
-	renderedColumns[m.currColumnId] = selectedTitleCellStyle.Copy().
-		Width(width).
-		MaxWidth(width).
-		Render(m.Columns[m.currColumnId].Title)
-	remainingWidth -= width
-
-	for i := range m.Columns {
-		if i != m.currColumnId && remainingWidth-m.colMaxWidths[i] > 0 {
-			renderedColumns[i] = titleCellStyle.Copy().
-				Width(m.colMaxWidths[i]).
-				MaxWidth(m.colMaxWidths[i]).
-				Render(m.Columns[i].Title)
-			remainingWidth -= m.colMaxWidths[i]
+func (m *Model) renderHeader() string {
+	headerColumns := make([]string, len(m.shown))
+	for i, c := range m.shown {
+		style := titleCellStyle
+		if c.id == m.currColumnId {
+			style = selectedTitleCellStyle
 		}
+		headerColumns[i] = style.Copy().
+			Width(c.width).
+			MaxWidth(c.width).
+			Render(m.columnTitle(c.id))
 	}
-
-	return renderedColumns
-}
-
-func (m *Model) renderHeader() string {
-	headerColumns := m.renderHeaderColumns()
 	header := lipgloss.JoinHorizontal(lipgloss.Top, headerColumns...)
 	return headerStyle.Copy().
 		Width(m.width).
@@ -362,34 +588,68 @@ func (m *Model) renderHeader() string {
 func (m *Model) renderBody() string {
 	bodyStyle := lipgloss.NewStyle().
 		Height(m.height - headerHeight)
-	if len(m.filteredRows) == 0 {
+	if len(m.filtered) == 0 {
 		return bodyStyle.Copy().PaddingLeft(1).Render(m.noDataLabel)
 	}
 	return m.rowsViewport.View()
 }
 
-func (m *Model) renderRow(rowId int, headerColumns []string) string {
-	// Rendering is slow, only draw rows that will actually be visible
-	rowsPerPage := m.rowsViewport.GetNumRowsPerPage()
-	if rowId < m.rowsViewport.GetCurrItem()-rowsPerPage || rowId > m.rowsViewport.GetCurrItem()+rowsPerPage {
-		return "\n"
+func (m *Model) renderRow(rowId int) string {
+	selected := m.rowsViewport.GetCurrItem() == rowId
+	if rendered, ok := m.renderedRows[rowId]; ok && !selected {
+		return rendered
 	}
 
-	var style lipgloss.Style
-	if m.rowsViewport.GetCurrItem() == rowId {
+	style := cellStyle
+	if selected {
 		style = selectedCellStyle
-	} else {
-		style = cellStyle
+	}
+	if m.marked[m.filtered[rowId]] {
+		style = style.Copy().Foreground(markedRowColor)
 	}
 
-	renderedColumns := make([]string, len(m.Columns))
-	for i, column := range m.filteredRows[rowId] {
-		colWidth := lipgloss.Width(headerColumns[i])
-		col := style.Copy().Width(colWidth).MaxWidth(colWidth).Render(column)
+	row, text := m.GetRowAt(rowId), m.texts[m.filtered[rowId]]
+	renderedColumns := make([]string, 0, len(m.shown))
+	for _, c := range m.shown {
+		if c.id >= len(text) {
+			continue
+		}
+		col := renderCell(style, text[c.id], m.query.Highlights(row, text, c.id), c.width)
 		renderedColumns = append(renderedColumns, col)
 	}
 
-	return rowStyle.Copy().Render(
+	rendered := rowStyle.Copy().Render(
 		lipgloss.JoinHorizontal(lipgloss.Top, renderedColumns...),
 	)
+	if !selected {
+		m.renderedRows[rowId] = rendered
+	}
+	return rendered
+}
+
+// Renders a cell with the spans matched by the search highlighted
+func renderCell(style lipgloss.Style, text string, spans [][2]int, width int) string {
+	if len(spans) == 0 {
+		return style.Copy().Width(width).MaxWidth(width).Render(text)
+	}
+
+	// Each piece is coloured on its own, as the end of a highlight would reset an outer colour
+	plain := style.Copy().UnsetPaddingLeft().UnsetPaddingRight()
+	highlighted := plain.Copy().Inherit(matchStyle).Foreground(matchStyle.GetForeground())
+	var b strings.Builder
+	prev := 0
+	for _, s := range spans {
+		b.WriteString(plain.Render(text[prev:s[0]]))
+		b.WriteString(highlighted.Render(text[s[0]:s[1]]))
+		prev = s[1]
+	}
+	b.WriteString(plain.Render(text[prev:]))
+
+	return lipgloss.NewStyle().
+		PaddingLeft(style.GetPaddingLeft()).
+		PaddingRight(style.GetPaddingRight()).
+		MaxHeight(1).
+		Width(width).
+		MaxWidth(width).
+		Render(b.String())
 }
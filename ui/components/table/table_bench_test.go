@@ -0,0 +1,109 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Big enough for the longest lists sawsy is expected to show, e.g. the objects under a prefix
+const benchRows = 100_000
+
+func benchmarkRows() []Row {
+	created := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := make([]Row, benchRows)
+	for i := range rows {
+		rows[i] = Row{
+			fmt.Sprintf("function-%d", i),
+			"eu-west-1",
+			Bytes(i * 1000),
+			created.Add(time.Duration(i) * time.Minute),
+			time.Duration(i) * time.Second,
+		}
+	}
+	return rows
+}
+
+func newBenchmarkTable(b *testing.B) *Model {
+	b.Helper()
+	m := newTestTable("Name", "Region", "Size", "Modified", "Duration")
+	m.AppendRows(benchmarkRows())
+	b.ResetTimer()
+	return m
+}
+
+func BenchmarkAppendRows(b *testing.B) {
+	rows := benchmarkRows()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m := newTestTable("Name", "Region", "Size", "Modified", "Duration")
+		m.AppendRows(rows)
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	m := newBenchmarkTable(b)
+	// Alternated, as searching for the same thing again does nothing
+	searches := []string{"function-4 Size>1MB", "/-9+$/"}
+	for i := 0; i < b.N; i++ {
+		m.filter(searches[i%len(searches)])
+	}
+}
+
+func BenchmarkCursorDown(b *testing.B) {
+	m := newBenchmarkTable(b)
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	for i := 0; i < b.N; i++ {
+		if m.GetCurrentItem() == benchRows-1 {
+			m.rowsViewport.FirstItem()
+		}
+		m.Update(down)
+	}
+}
+
+func BenchmarkView(b *testing.B) {
+	m := newBenchmarkTable(b)
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	for i := 0; i < b.N; i++ {
+		// Moving each time so the selected row is drawn again, as it would be
+		m.Update(down)
+		_ = m.View()
+	}
+}
+
+func BenchmarkMarkAll(b *testing.B) {
+	m := newBenchmarkTable(b)
+	for i := 0; i < b.N; i++ {
+		m.toggleMarkAll()
+	}
+}
+
+// A page of rows arriving for a long table that's sorted, as when scrolling to the end of it
+func BenchmarkAppendPageSorted(b *testing.B) {
+	m := newBenchmarkTable(b)
+	m.currColumnId = 2
+	m.toggleSort()
+	page := benchmarkRows()[:1000]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.AppendRows(page)
+	}
+}
+
+func TestRendersOnlyVisibleRows(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a table of 100k rows")
+	}
+	m := newTestTable("Name", "Region", "Size", "Modified", "Duration")
+	m.AppendRows(benchmarkRows())
+	m.currColumnId = 2
+	m.toggleSort()
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.View()
+
+	if n := len(m.renderedRows); n == 0 || n > m.height {
+		t.Errorf("rendered %d rows, want at most the %d that fit", n, m.height)
+	}
+}
@@ -14,7 +14,19 @@ var (
 		Faint(true).
 		Padding(0, 2)
 
-	activeTab = tab.
+	activeTab lipgloss.Style
+
+	tabsRow = lipgloss.NewStyle().
+		Height(tabsContentHeight).
+		PaddingTop(1).
+		PaddingBottom(0)
+
+	activeAwsAccount lipgloss.Style
+)
+
+func init() {
+	styles.OnThemeChange(func() {
+		activeTab = tab.
 			Copy().
 			Faint(false).
 			Bold(true).
@@ -23,12 +35,8 @@ var (
 			BorderStyle(lipgloss.ThickBorder()).
 			BorderBottomForeground(styles.Theme.HighlightTab)
 
-	tabsRow = lipgloss.NewStyle().
-		Height(tabsContentHeight).
-		PaddingTop(1).
-		PaddingBottom(0)
-
-	activeAwsAccount = lipgloss.NewStyle().
-				PaddingRight(2).
-				Foreground(styles.Theme.PageMetaText)
-)
+		activeAwsAccount = lipgloss.NewStyle().
+			PaddingRight(2).
+			Foreground(styles.Theme.PageMetaText)
+	})
+}
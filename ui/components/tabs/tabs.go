@@ -18,6 +18,7 @@ type Model struct {
 	ctx          *context.ProgramContext
 	Tabs         []Tab
 	CurrentTabId int
+	width        int // Defaults to the width of the screen
 }
 
 func NewModel(ctx *context.ProgramContext, tabs []Tab) Model {
@@ -48,11 +49,19 @@ func (m Model) View() string {
 
 	accountId := activeAwsAccount.Render(
 		fmt.Sprintf(
-			"%s (%s) | %s",
+			"%s (%s) %s/%s | %s",
 			icons.AWS, m.ctx.AwsAccountId,
+			m.ctx.AwsProfile, m.ctx.AwsRegion,
 			m.ctx.AwsService))
 
-	tabsWidth := m.ctx.ScreenWidth - lipgloss.Width(accountId)
+	width := m.width
+	if width == 0 {
+		width = m.ctx.ScreenWidth
+	}
+	tabsWidth := width - lipgloss.Width(accountId)
+	if tabsWidth < 0 {
+		tabsWidth = 0
+	}
 
 	renderedTabs := lipgloss.NewStyle().
 		Width(tabsWidth).
@@ -60,11 +69,15 @@ func (m Model) View() string {
 		Render(lipgloss.JoinHorizontal(lipgloss.Top, tabs...))
 
 	return tabsRow.Copy().
-		Width(m.ctx.ScreenWidth).
-		MaxWidth(m.ctx.ScreenWidth).
+		Width(width).
+		MaxWidth(width).
 		Render(lipgloss.JoinHorizontal(lipgloss.Top, renderedTabs, accountId))
 }
 
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
 func (m *Model) NextTab() int {
 	m.CurrentTabId = (m.CurrentTabId + 1) % len(m.Tabs)
 	return m.CurrentTabId
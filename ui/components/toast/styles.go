@@ -0,0 +1,18 @@
+package toast
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danielcmessias/sawsy/ui/styles"
+)
+
+var (
+	messageStyle lipgloss.Style
+	errorStyle   lipgloss.Style
+)
+
+func init() {
+	styles.OnThemeChange(func() {
+		messageStyle = lipgloss.NewStyle().Foreground(styles.Theme.MainText)
+		errorStyle = lipgloss.NewStyle().Foreground(styles.Theme.ErrorText)
+	})
+}
@@ -0,0 +1,78 @@
+package toast
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/ui/context"
+	"github.com/danielcmessias/sawsy/ui/styles"
+)
+
+const duration = 4 * time.Second
+
+// A short-lived message shown in the footer, in place of the help
+type Model struct {
+	ctx     *context.ProgramContext
+	message string
+	isError bool
+	id      int
+}
+
+// Shows a toast. Any component can return a command producing this.
+type ShowMsg struct {
+	Message string
+	IsError bool
+}
+
+type hideMsg struct {
+	id int
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	return Model{ctx: ctx}
+}
+
+func Show(message string) tea.Cmd {
+	return func() tea.Msg {
+		return ShowMsg{Message: message}
+	}
+}
+
+func ShowError(message string) tea.Cmd {
+	return func() tea.Msg {
+		return ShowMsg{Message: message, IsError: true}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ShowMsg:
+		m.message = msg.Message
+		m.isError = msg.IsError
+		// A newer toast replaces this one, so only hide the toast the tick was started for
+		m.id++
+		id := m.id
+		return m, tea.Tick(duration, func(time.Time) tea.Msg {
+			return hideMsg{id: id}
+		})
+	case hideMsg:
+		if msg.id == m.id {
+			m.message = ""
+		}
+	}
+	return m, nil
+}
+
+func (m Model) Visible() bool {
+	return m.message != ""
+}
+
+func (m Model) View() string {
+	style := messageStyle
+	if m.isError {
+		style = errorStyle
+	}
+	return styles.FooterStyle.Copy().
+		Width(m.ctx.ScreenWidth).
+		Render(style.Render(m.message))
+}
@@ -11,6 +11,8 @@ type ProgramContext struct {
 	MainContentWidth  int
 	MainContentHeight int
 	AwsAccountId      string
+	AwsProfile        string
+	AwsRegion         string
 	AwsService        string
 	Config            *config.Config
 
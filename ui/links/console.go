@@ -0,0 +1,116 @@
+package links
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/danielcmessias/sawsy/ui/pages/glue"
+	"github.com/danielcmessias/sawsy/ui/pages/iam"
+	"github.com/danielcmessias/sawsy/ui/pages/lakeformation"
+	"github.com/danielcmessias/sawsy/ui/pages/lambda"
+	"github.com/danielcmessias/sawsy/ui/pages/rds"
+	"github.com/danielcmessias/sawsy/ui/pages/s3"
+)
+
+// The AWS console's page for where a link goes, in region unless the resource is known to be
+// elsewhere. Returns false for pages the console has nothing like, e.g. profiles.
+func ConsoleURL(link Link, region string, accountId string) (string, bool) {
+	q := url.QueryEscape
+	// Fragments are read by the console's own router, which wants path segments escaped
+	p := url.PathEscape
+
+	switch c := link.Context.(type) {
+	case glue.JobPageContext:
+		return regionalURL(region, "gluestudio", "#/editor/job/"+p(c.JobName)+"/details"), true
+
+	case iam.UserPageContext:
+		return iamURL("#/users/details/" + p(c.UserName)), true
+	case iam.RolePageContext:
+		return iamURL("#/roles/details/" + p(c.RoleName)), true
+	case iam.PolicyPageContext:
+		// Inline policies are shown with whoever has them
+		switch {
+		case c.PolicyArn != "":
+			return iamURL("#/policies/details/" + p(c.PolicyArn)), true
+		case c.UserName != "":
+			return iamURL("#/users/details/" + p(c.UserName) + "?section=permissions"), true
+		case c.RoleName != "":
+			return iamURL("#/roles/details/" + p(c.RoleName) + "?section=permissions"), true
+		}
+		return "", false
+
+	case lakeformation.DatabasePageContext:
+		return regionalURL(region, "lakeformation", fmt.Sprintf(
+			"#database-details?database=%s&catalogId=%s",
+			q(c.DatabaseName),
+			q(accountId),
+		)), true
+	case lakeformation.TablePageContext:
+		return regionalURL(region, "lakeformation", fmt.Sprintf(
+			"#table-details?database=%s&table=%s&catalogId=%s",
+			q(c.DatabaseName),
+			q(c.TableName),
+			q(accountId),
+		)), true
+
+	case lambda.FunctionPageContext:
+		return regionalURL(region, "lambda", "#/functions/"+p(c.FunctionName)), true
+
+	case rds.InstancePageContext:
+		return regionalURL(region, "rds", "#database:id="+p(c.InstanceId)+";is-cluster=false"), true
+
+	case s3.BucketPageContext:
+		if c.Region != "" {
+			region = c.Region
+		}
+		return fmt.Sprintf(
+			"https://s3.console.aws.amazon.com/s3/buckets/%s?region=%s&prefix=%s",
+			p(c.Bucket),
+			q(region),
+			q(c.Prefix),
+		), true
+	case s3.ObjectPageContext:
+		if c.Region != "" {
+			region = c.Region
+		}
+		return fmt.Sprintf(
+			"https://s3.console.aws.amazon.com/s3/object/%s?region=%s&prefix=%s",
+			p(c.Bucket),
+			q(region),
+			q(c.Key),
+		), true
+
+	case nil:
+		// The service's own front page
+		switch link.Page {
+		case "glue":
+			return regionalURL(region, "glue", ""), true
+		case "iam":
+			return iamURL("#/home"), true
+		case "lakeformation":
+			return regionalURL(region, "lakeformation", "#databases"), true
+		case "lambda":
+			return regionalURL(region, "lambda", "#/functions"), true
+		case "rds":
+			return regionalURL(region, "rds", "#databases:"), true
+		case "s3":
+			return "https://s3.console.aws.amazon.com/s3/buckets?region=" + q(region), true
+		}
+	}
+	return "", false
+}
+
+func regionalURL(region string, service string, fragment string) string {
+	return fmt.Sprintf(
+		"https://%s.console.aws.amazon.com/%s/home?region=%s%s",
+		region,
+		service,
+		url.QueryEscape(region),
+		fragment,
+	)
+}
+
+// IAM isn't regional
+func iamURL(fragment string) string {
+	return "https://console.aws.amazon.com/iam/home" + fragment
+}
@@ -0,0 +1,270 @@
+package links
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/danielcmessias/sawsy/ui/pages/glue"
+	"github.com/danielcmessias/sawsy/ui/pages/iam"
+	"github.com/danielcmessias/sawsy/ui/pages/lakeformation"
+	"github.com/danielcmessias/sawsy/ui/pages/lambda"
+	"github.com/danielcmessias/sawsy/ui/pages/rds"
+	"github.com/danielcmessias/sawsy/ui/pages/s3"
+)
+
+// Somewhere to go in the program: a page and the context it needs, e.g. which bucket to show
+type Link struct {
+	Page    string
+	Context interface{}
+}
+
+// Pages about a single resource, with the names of the values identifying it in the order they're
+// given, e.g. `rds/instance my-db`
+var resourcePages = map[string][]string{
+	"glue/job":               {"job name"},
+	"iam/user":               {"user name"},
+	"iam/role":               {"role name"},
+	"iam/policy":             {"policy ARN"},
+	"lakeformation/database": {"database name"},
+	"lakeformation/table":    {"database name", "table name"},
+	"lambda/function":        {"function name"},
+	"rds/instance":           {"instance id"},
+	"s3/objects":             {"bucket", "prefix"},
+	"s3/object":              {"bucket", "key"},
+}
+
+// Parses where to go from any of
+//
+//	rds                       a page by name
+//	rds/instance my-db        a page about a single resource, followed by what identifies it
+//	s3://bucket/prefix/       an S3 URI, a key without a trailing slash opens the object
+//	arn:aws:iam::123:role/foo an ARN
+func Parse(args []string) (Link, error) {
+	if len(args) == 0 {
+		return Link{}, fmt.Errorf("nowhere to go")
+	}
+	first := args[0]
+
+	switch {
+	case strings.HasPrefix(first, "s3://"):
+		return parseS3URI(first)
+	case strings.HasPrefix(first, "arn:"):
+		return ParseARN(first)
+	}
+
+	names, ok := resourcePages[first]
+	if !ok {
+		if len(args) > 1 {
+			return Link{}, fmt.Errorf("page %s doesn't take any arguments", first)
+		}
+		return Link{Page: first}, nil
+	}
+
+	values := args[1:]
+	// An S3 prefix is optional, the root of the bucket is as good a place as any
+	if first == "s3/objects" && len(values) == 1 {
+		values = append(values, "")
+	}
+	if len(values) != len(names) {
+		return Link{}, fmt.Errorf("%s needs: %s", first, strings.Join(names, ", "))
+	}
+	return resourceLink(first, values), nil
+}
+
+// Like Parse, for a link typed as one line, e.g. into the go to prompt. Values with spaces in them
+// are double quoted, as Format writes them.
+func ParseString(s string) (Link, error) {
+	s = strings.TrimSpace(s)
+	// S3 keys can have spaces in them
+	if strings.HasPrefix(s, "s3://") || strings.HasPrefix(s, "arn:") {
+		return Parse([]string{s})
+	}
+	fields, err := splitFields(s)
+	if err != nil {
+		return Link{}, err
+	}
+	return Parse(fields)
+}
+
+// Splits s on spaces, except inside double quotes
+func splitFields(s string) ([]string, error) {
+	var fields []string
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			return fields, nil
+		}
+		if s[0] != '"' {
+			end := strings.IndexFunc(s, unicode.IsSpace)
+			if end < 0 {
+				end = len(s)
+			}
+			fields = append(fields, s[:end])
+			s = s[end:]
+			continue
+		}
+
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("unterminated quote in %s", s)
+		}
+		field, _ := strconv.Unquote(quoted)
+		fields = append(fields, field)
+		s = s[len(quoted):]
+	}
+}
+
+// Quotes a value if ParseString would otherwise split it, or lose it
+func quoteField(v string) string {
+	if v == "" || strings.HasPrefix(v, `"`) || strings.IndexFunc(v, unicode.IsSpace) >= 0 {
+		return strconv.Quote(v)
+	}
+	return v
+}
+
+func resourceLink(pageName string, v []string) Link {
+	var context interface{}
+	switch pageName {
+	case "glue/job":
+		context = glue.JobPageContext{JobName: v[0]}
+	case "iam/user":
+		context = iam.UserPageContext{UserName: v[0]}
+	case "iam/role":
+		context = iam.RolePageContext{RoleName: v[0]}
+	case "iam/policy":
+		context = iam.PolicyPageContext{PolicyArn: v[0]}
+	case "lakeformation/database":
+		context = lakeformation.DatabasePageContext{DatabaseName: v[0]}
+	case "lakeformation/table":
+		context = lakeformation.TablePageContext{DatabaseName: v[0], TableName: v[1]}
+	case "lambda/function":
+		context = lambda.FunctionPageContext{FunctionName: v[0]}
+	case "rds/instance":
+		context = rds.InstancePageContext{InstanceId: v[0]}
+	// The bucket's region is looked up when it's first needed
+	case "s3/objects":
+		context = s3.BucketPageContext{Bucket: v[0], Prefix: v[1]}
+	case "s3/object":
+		context = s3.ObjectPageContext{Bucket: v[0], Key: v[1]}
+	}
+	return Link{Page: pageName, Context: context}
+}
+
+func parseS3URI(uri string) (Link, error) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(uri, "s3://"), "/")
+	if bucket == "" {
+		return Link{}, fmt.Errorf("no bucket in %s", uri)
+	}
+	return s3Link(bucket, key), nil
+}
+
+func s3Link(bucket string, key string) Link {
+	if key == "" || strings.HasSuffix(key, "/") {
+		return resourceLink("s3/objects", []string{bucket, key})
+	}
+	return resourceLink("s3/object", []string{bucket, key})
+}
+
+// Parses the ARN of a resource that has a page. The region and account in the ARN are ignored, the
+// resource is looked for using the current profile and region.
+func ParseARN(arn string) (Link, error) {
+	// arn:partition:service:region:account-id:resource
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return Link{}, fmt.Errorf("%s is not an ARN", arn)
+	}
+	service, resource := parts[2], parts[5]
+
+	// Resources are either type/id or type:id
+	resourceType, id, ok := strings.Cut(resource, "/")
+	if t, i, found := strings.Cut(resource, ":"); found && (!ok || len(t) < len(resourceType)) {
+		resourceType, id, ok = t, i, true
+	}
+
+	switch {
+	case service == "s3":
+		if resource == "" {
+			break
+		}
+		bucket, key, _ := strings.Cut(resource, "/")
+		return s3Link(bucket, key), nil
+
+	case service == "iam" && resourceType == "user" && ok:
+		return resourceLink("iam/user", []string{lastPathElement(id)}), nil
+	case service == "iam" && resourceType == "role" && ok:
+		return resourceLink("iam/role", []string{lastPathElement(id)}), nil
+	case service == "iam" && resourceType == "policy" && ok:
+		return resourceLink("iam/policy", []string{arn}), nil
+
+	case service == "rds" && resourceType == "db" && ok:
+		return resourceLink("rds/instance", []string{id}), nil
+
+	case service == "lambda" && resourceType == "function" && ok:
+		// Drop any version or alias
+		name, _, _ := strings.Cut(id, ":")
+		return resourceLink("lambda/function", []string{name}), nil
+
+	case service == "glue" && resourceType == "job" && ok:
+		return resourceLink("glue/job", []string{id}), nil
+	case service == "glue" && resourceType == "database" && ok:
+		return resourceLink("lakeformation/database", []string{id}), nil
+	case service == "glue" && resourceType == "table" && ok:
+		database, table, found := strings.Cut(id, "/")
+		if found {
+			return resourceLink("lakeformation/table", []string{database, table}), nil
+		}
+	}
+	return Link{}, fmt.Errorf("no page for %s", arn)
+}
+
+// IAM names can be preceded by a path, e.g. role/service-role/foo
+func lastPathElement(id string) string {
+	return id[strings.LastIndex(id, "/")+1:]
+}
+
+// Whether a page is about a single resource, so can't be shown without a context saying which
+func IsResourcePage(pageName string) bool {
+	_, ok := resourcePages[pageName]
+	return ok
+}
+
+// Writes a link the way Parse reads it, e.g. `rds/instance my-db`. Returns false for links Parse
+// has no way of writing, like inline IAM policies.
+func Format(link Link) (string, bool) {
+	var values []string
+	switch c := link.Context.(type) {
+	case nil:
+		return link.Page, !IsResourcePage(link.Page)
+	case glue.JobPageContext:
+		values = []string{c.JobName}
+	case iam.UserPageContext:
+		values = []string{c.UserName}
+	case iam.RolePageContext:
+		values = []string{c.RoleName}
+	case iam.PolicyPageContext:
+		if c.PolicyArn == "" {
+			return "", false
+		}
+		values = []string{c.PolicyArn}
+	case lakeformation.DatabasePageContext:
+		values = []string{c.DatabaseName}
+	case lakeformation.TablePageContext:
+		values = []string{c.DatabaseName, c.TableName}
+	case lambda.FunctionPageContext:
+		values = []string{c.FunctionName}
+	case rds.InstancePageContext:
+		values = []string{c.InstanceId}
+	case s3.BucketPageContext:
+		return "s3://" + c.Bucket + "/" + c.Prefix, true
+	case s3.ObjectPageContext:
+		return "s3://" + c.Bucket + "/" + c.Key, true
+	default:
+		return "", false
+	}
+	for i, v := range values {
+		values[i] = quoteField(v)
+	}
+	return link.Page + " " + strings.Join(values, " "), true
+}
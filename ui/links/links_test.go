@@ -0,0 +1,45 @@
+package links
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/danielcmessias/sawsy/ui/pages/glue"
+	"github.com/danielcmessias/sawsy/ui/pages/lakeformation"
+	"github.com/danielcmessias/sawsy/ui/pages/rds"
+	"github.com/danielcmessias/sawsy/ui/pages/s3"
+)
+
+func TestFormatRoundTrips(t *testing.T) {
+	tests := []Link{
+		{Page: "rds"},
+		{Page: "rds/instance", Context: rds.InstancePageContext{InstanceId: "my-db"}},
+		{Page: "glue/job", Context: glue.JobPageContext{JobName: "nightly load"}},
+		{Page: "glue/job", Context: glue.JobPageContext{JobName: `"quoted"`}},
+		{Page: "lakeformation/table", Context: lakeformation.TablePageContext{DatabaseName: "sales db", TableName: "orders"}},
+		{Page: "lakeformation/table", Context: lakeformation.TablePageContext{DatabaseName: "", TableName: "t"}},
+		{Page: "s3/objects", Context: s3.BucketPageContext{Bucket: "b", Prefix: "my logs/"}},
+		{Page: "s3/object", Context: s3.ObjectPageContext{Bucket: "b", Key: "a b.txt"}},
+	}
+	for _, want := range tests {
+		formatted, ok := Format(want)
+		if !ok {
+			t.Errorf("Format(%+v) failed", want)
+			continue
+		}
+		got, err := ParseString(formatted)
+		if err != nil {
+			t.Errorf("ParseString(%q): %v", formatted, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseString(%q) = %+v, want %+v", formatted, got, want)
+		}
+	}
+}
+
+func TestParseStringUnterminatedQuote(t *testing.T) {
+	if _, err := ParseString(`glue/job "nightly load`); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+}
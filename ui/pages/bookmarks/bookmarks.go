@@ -0,0 +1,164 @@
+package bookmarks
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/config"
+	"github.com/danielcmessias/sawsy/data"
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/ui/components/toast"
+	"github.com/danielcmessias/sawsy/ui/context"
+	"github.com/danielcmessias/sawsy/ui/links"
+	"github.com/danielcmessias/sawsy/ui/pages/s3"
+)
+
+type BookmarksPageModel struct {
+	page.Model
+	ctx *context.ProgramContext
+}
+
+func NewBookmarksPage(ctx *context.ProgramContext) *BookmarksPageModel {
+	return &BookmarksPageModel{
+		Model: page.New(ctx, bookmarksPageSpec),
+		ctx:   ctx,
+	}
+}
+
+func (m *BookmarksPageModel) FetchData(client *data.Client) tea.Cmd {
+	var rows []table.Row
+	for _, b := range m.ctx.Config.Bookmarks {
+		link, err := Resolve(b)
+		pageName := link.Page
+		if err != nil {
+			pageName = "?"
+		}
+		rows = append(rows, table.WithObject(table.Row{b.Name, pageName, b.Link}, b))
+	}
+
+	return func() tea.Msg {
+		return page.NewRowsMsg{
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Bookmarks"),
+			Rows:      rows,
+			Overwrite: true,
+		}
+	}
+}
+
+func (m *BookmarksPageModel) Update(client *data.Client, msg tea.Msg) (tea.Cmd, bool) {
+	if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.ctx.Keys.Unbookmark) && !m.ctx.LockKeyboardCapture {
+		row, ok := m.CurrentRow()
+		if !ok {
+			return nil, true
+		}
+		return tea.Batch(Remove(m.ctx, row["Name"]), m.FetchData(client)), true
+	}
+	return m.Model.Update(client, msg)
+}
+
+func (m *BookmarksPageModel) Inspect(client *data.Client) tea.Cmd {
+	if _, _, ok := m.InspectTarget(); !ok {
+		if row, ok := m.CurrentRow(); ok {
+			return toast.ShowError(fmt.Sprintf("Can't open %s", row["Link"]))
+		}
+	}
+	return page.InspectSelected(m)
+}
+
+func (m *BookmarksPageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
+	if !ok {
+		return "", nil, false
+	}
+	for _, b := range m.ctx.Config.Bookmarks {
+		if b.Name != row["Name"] {
+			continue
+		}
+		link, err := Resolve(b)
+		if err != nil {
+			return "", nil, false
+		}
+		return link.Page, link.Context, true
+	}
+	return "", nil, false
+}
+
+// Makes a bookmark of link, or returns false if it can't be written down, like the raw view of a row
+func New(name string, link links.Link) (config.Bookmark, bool) {
+	formatted, ok := links.Format(link)
+	if !ok {
+		return config.Bookmark{}, false
+	}
+	bookmark := config.Bookmark{Name: name, Link: formatted}
+	switch c := link.Context.(type) {
+	case s3.BucketPageContext:
+		bookmark.Region = c.Region
+	case s3.ObjectPageContext:
+		bookmark.Region = c.Region
+	}
+	return bookmark, true
+}
+
+// Where a bookmark goes, with the region of its bucket filled in if it's known
+func Resolve(b config.Bookmark) (links.Link, error) {
+	link, err := links.ParseString(b.Link)
+	if err != nil {
+		return link, err
+	}
+	switch c := link.Context.(type) {
+	case s3.BucketPageContext:
+		c.Region = b.Region
+		link.Context = c
+	case s3.ObjectPageContext:
+		c.Region = b.Region
+		link.Context = c
+	}
+	return link, nil
+}
+
+// Adds a bookmark to the config, replacing any with the same name, and saves it
+func Add(ctx *context.ProgramContext, bookmark config.Bookmark) tea.Cmd {
+	replaced := false
+	for i, b := range ctx.Config.Bookmarks {
+		if b.Name == bookmark.Name {
+			ctx.Config.Bookmarks[i] = bookmark
+			replaced = true
+		}
+	}
+	if !replaced {
+		ctx.Config.Bookmarks = append(ctx.Config.Bookmarks, bookmark)
+	}
+
+	message := fmt.Sprintf("Bookmarked %s", bookmark.Name)
+	if replaced {
+		message = fmt.Sprintf("Replaced bookmark %s", bookmark.Name)
+	}
+	return save(ctx.Config.Bookmarks, message)
+}
+
+// Removes the bookmark called name from the config, and saves it
+func Remove(ctx *context.ProgramContext, name string) tea.Cmd {
+	var bookmarks []config.Bookmark
+	for _, b := range ctx.Config.Bookmarks {
+		if b.Name != name {
+			bookmarks = append(bookmarks, b)
+		}
+	}
+	ctx.Config.Bookmarks = bookmarks
+	return save(bookmarks, fmt.Sprintf("Removed bookmark %s", name))
+}
+
+func save(bookmarks []config.Bookmark, message string) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.SaveBookmarks(bookmarks); err != nil {
+			return toast.ShowMsg{
+				Message: fmt.Sprintf("Couldn't save the bookmarks: %v", err),
+				IsError: true,
+			}
+		}
+		return toast.ShowMsg{Message: message}
+	}
+}
@@ -0,0 +1,31 @@
+package bookmarks
+
+import (
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/pane"
+	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/utils/icons"
+)
+
+var bookmarksPageSpec = page.PageSpec{
+	Name: "bookmarks",
+	PaneSpecs: []pane.PaneSpec{
+		table.TableSpec{
+			BaseSpec: pane.BaseSpec{
+				Name: "Bookmarks",
+				Icon: icons.BOOKMARK,
+			},
+			Columns: []table.Column{
+				{
+					Title: "Name",
+				},
+				{
+					Title: "Page",
+				},
+				{
+					Title: "Link",
+				},
+			},
+		},
+	},
+}
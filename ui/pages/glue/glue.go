@@ -1,12 +1,9 @@
 package glue
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/page"
-	"github.com/danielcmessias/sawsy/ui/components/table"
 	"github.com/danielcmessias/sawsy/ui/context"
 )
 
@@ -27,20 +24,34 @@ func (m *GluePageModel) FetchData(client *data.Client) tea.Cmd {
 	)
 }
 
+func (m *GluePageModel) FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd {
+	switch paneId {
+	case m.GetPaneId("Jobs"):
+		return m.fetchJobs(client, nextToken)
+	case m.GetPaneId("Crawlers"):
+		return m.fetchCrawlers(client, nextToken)
+	default:
+		return nil
+	}
+}
+
 func (m *GluePageModel) fetchJobs(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.Glue.GetJobsRows(nextToken)
+		rows, next, err := client.Glue.GetJobsRows(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Jobs"),
+				Err:      err,
+				RetryCmd: m.fetchJobs(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Jobs"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchJobs(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Jobs"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
@@ -48,48 +59,39 @@ func (m *GluePageModel) fetchJobs(client *data.Client, nextToken *string) tea.Cm
 
 func (m *GluePageModel) fetchCrawlers(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.Glue.GetCrawlersRows(nextToken)
+		rows, next, err := client.Glue.GetCrawlersRows(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Crawlers"),
+				Err:      err,
+				RetryCmd: m.fetchCrawlers(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Crawlers"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchCrawlers(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Crawlers"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
 }
 
 func (m *GluePageModel) Inspect(client *data.Client) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
+	return page.InspectSelected(m)
+}
+
+func (m *GluePageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
 	if !ok {
-		log.Fatal("This pane is not a table")
+		return "", nil, false
 	}
-	row := table.GetCurrentRowMarshalled()
-	var nextPage string
-	var pageContext interface{}
 
-	switch m.Tabs.CurrentTabId {
+	switch m.GetCurrentPaneId() {
 	case m.GetPaneId("Jobs"):
-		nextPage = "glue/job"
-		pageContext = JobPageContext{
-			JobName: row["Name"],
-		}
-	case m.GetPaneId("Crawlers"):
-		return nil
-	}
-
-	changePageCmd := func() tea.Msg {
-		return page.ChangePageMsg{
-			NewPage:     nextPage,
-			FetchData:   true,
-			PageContext: pageContext,
-		}
+		return "glue/job", JobPageContext{JobName: row["Name"]}, true
 	}
-	return changePageCmd
+	return "", nil, false
 }
@@ -1,8 +1,6 @@
 package glue
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/code"
@@ -36,7 +34,12 @@ func (m *JobPageModel) fetchDetails(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
 		rows, err := client.Glue.GetJobDetails(m.Context.(JobPageContext).JobName)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Details"),
+				Err:      err,
+				RetryCmd: m.fetchDetails(client),
+			}
 		}
 
 		msg := page.NewRowsMsg{
@@ -52,7 +55,12 @@ func (m *JobPageModel) fetchRuns(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
 		rows, err := client.Glue.GetJobRuns(m.Context.(JobPageContext).JobName)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Runs"),
+				Err:      err,
+				RetryCmd: m.fetchRuns(client),
+			}
 		}
 
 		msg := page.NewRowsMsg{
@@ -68,7 +76,12 @@ func (m *JobPageModel) fetchScript(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
 		script, location, err := client.Glue.GetJobScript(m.Context.(JobPageContext).JobName)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Script"),
+				Err:      err,
+				RetryCmd: m.fetchScript(client),
+			}
 		}
 
 		msg := code.NewCodeContentMsg{
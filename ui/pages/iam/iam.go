@@ -1,12 +1,9 @@
 package iam
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/page"
-	"github.com/danielcmessias/sawsy/ui/components/table"
 	"github.com/danielcmessias/sawsy/ui/context"
 )
 
@@ -27,20 +24,34 @@ func (m *IAMPageModel) FetchData(client *data.Client) tea.Cmd {
 	)
 }
 
+func (m *IAMPageModel) FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd {
+	switch paneId {
+	case m.GetPaneId("Users"):
+		return m.fetchUsers(client, nextToken)
+	case m.GetPaneId("Roles"):
+		return m.fetchRoles(client, nextToken)
+	default:
+		return nil
+	}
+}
+
 func (m *IAMPageModel) fetchUsers(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.IAM.GetUsers(nextToken)
+		rows, next, err := client.IAM.GetUsers(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Users"),
+				Err:      err,
+				RetryCmd: m.fetchUsers(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Users"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchUsers(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Users"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
@@ -48,53 +59,41 @@ func (m *IAMPageModel) fetchUsers(client *data.Client, nextToken *string) tea.Cm
 
 func (m *IAMPageModel) fetchRoles(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.IAM.GetRoles(nextToken)
+		rows, next, err := client.IAM.GetRoles(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Roles"),
+				Err:      err,
+				RetryCmd: m.fetchRoles(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Roles"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchRoles(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Roles"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
 }
 
 func (m *IAMPageModel) Inspect(client *data.Client) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
+	return page.InspectSelected(m)
+}
+
+func (m *IAMPageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
 	if !ok {
-		log.Fatal("This pane is not a table")
+		return "", nil, false
 	}
 
-	row := table.GetCurrentRowMarshalled()
-
 	switch m.GetCurrentPaneId() {
 	case m.GetPaneId("Users"):
-		return func() tea.Msg {
-			return page.ChangePageMsg{
-				NewPage:   "iam/user",
-				FetchData: true,
-				PageContext: UserPageContext{
-					UserName: row["Name"],
-				},
-			}
-		}
+		return "iam/user", UserPageContext{UserName: row["Name"]}, true
 	case m.GetPaneId("Roles"):
-		return func() tea.Msg {
-			return page.ChangePageMsg{
-				NewPage:   "iam/role",
-				FetchData: true,
-				PageContext: RolePageContext{
-					RoleName: row["Name"],
-				},
-			}
-		}
-	default:
-		return nil
+		return "iam/role", RolePageContext{RoleName: row["Name"]}, true
 	}
+	return "", nil, false
 }
@@ -1,8 +1,6 @@
 package iam
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/code"
@@ -52,7 +50,12 @@ func (m *PolicyPageModel) fetchPolicyPermissions(client *data.Client) tea.Cmd {
 			}
 		}
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Permissions"),
+				Err:      err,
+				RetryCmd: m.fetchPolicyPermissions(client),
+			}
 		}
 
 		msg := code.NewCodeContentMsg{
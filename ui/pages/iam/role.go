@@ -1,13 +1,10 @@
 package iam
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/code"
 	"github.com/danielcmessias/sawsy/ui/components/page"
-	"github.com/danielcmessias/sawsy/ui/components/table"
 	"github.com/danielcmessias/sawsy/ui/context"
 )
 
@@ -33,20 +30,34 @@ func (m *RolePageModel) FetchData(client *data.Client) tea.Cmd {
 	)
 }
 
+func (m *RolePageModel) FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd {
+	switch paneId {
+	case m.GetPaneId("Policies"):
+		return m.fetchRolePolicies(client, nextToken)
+	case m.GetPaneId("Tags"):
+		return m.fetchRoleTags(client, nextToken)
+	default:
+		return nil
+	}
+}
+
 func (m *RolePageModel) fetchRolePolicies(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.IAM.GetRolePolicies(m.Context.(RolePageContext).RoleName, nextToken)
+		rows, next, err := client.IAM.GetRolePolicies(m.Context.(RolePageContext).RoleName, nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Policies"),
+				Err:      err,
+				RetryCmd: m.fetchRolePolicies(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Policies"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchRolePolicies(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Policies"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
@@ -56,7 +67,12 @@ func (m *RolePageModel) fetchAssumeRolePolicy(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
 		policy, err := client.IAM.GetAssumeRolePolicy(m.Context.(RolePageContext).RoleName)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Trust Relationships"),
+				Err:      err,
+				RetryCmd: m.fetchAssumeRolePolicy(client),
+			}
 		}
 
 		msg := code.NewCodeContentMsg{
@@ -71,44 +87,43 @@ func (m *RolePageModel) fetchAssumeRolePolicy(client *data.Client) tea.Cmd {
 
 func (m *RolePageModel) fetchRoleTags(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.IAM.GetRoleTags(m.Context.(RolePageContext).RoleName, nextToken)
+		rows, next, err := client.IAM.GetRoleTags(m.Context.(RolePageContext).RoleName, nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Tags"),
+				Err:      err,
+				RetryCmd: m.fetchRoleTags(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Tags"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchRoleTags(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Tags"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
 }
 
 func (m *RolePageModel) Inspect(client *data.Client) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
+	return page.InspectSelected(m)
+}
+
+func (m *RolePageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
 	if !ok {
-		log.Fatal("This pane is not a table")
+		return "", nil, false
 	}
 
-	row := table.GetCurrentRowMarshalled()
 	switch m.GetCurrentPaneId() {
 	case m.GetPaneId("Policies"):
-		return func() tea.Msg {
-			return page.ChangePageMsg{
-				NewPage:   "iam/policy",
-				FetchData: true,
-				PageContext: PolicyPageContext{
-					RoleName:   m.Context.(RolePageContext).RoleName,
-					PolicyName: row["Name"],
-					PolicyArn:  row["ARN"],
-				},
-			}
-		}
-	default:
-		return nil
+		return "iam/policy", PolicyPageContext{
+			RoleName:   m.Context.(RolePageContext).RoleName,
+			PolicyName: row["Name"],
+			PolicyArn:  row["ARN"],
+		}, true
 	}
+	return "", nil, false
 }
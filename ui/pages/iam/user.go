@@ -1,12 +1,9 @@
 package iam
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/page"
-	"github.com/danielcmessias/sawsy/ui/components/table"
 	"github.com/danielcmessias/sawsy/ui/context"
 )
 
@@ -31,16 +28,33 @@ func (m *UserPageModel) FetchData(client *data.Client) tea.Cmd {
 	)
 }
 
+func (m *UserPageModel) FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd {
+	switch paneId {
+	case m.GetPaneId("Policies"):
+		return m.fetchUserPolicies(client, nextToken)
+	case m.GetPaneId("Tags"):
+		return m.fetchUserTags(client, nextToken)
+	default:
+		return nil
+	}
+}
+
 func (m *UserPageModel) fetchUserPolicies(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, _ := client.IAM.GetUserPolicies(m.Context.(UserPageContext).UserName, nextToken)
-		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Policies"),
-			Rows:   rows,
+		rows, next, err := client.IAM.GetUserPolicies(m.Context.(UserPageContext).UserName, nextToken)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Policies"),
+				Err:      err,
+				RetryCmd: m.fetchUserPolicies(client, nextToken),
+			}
 		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchUserPolicies(client, nextToken)
+		msg := page.NewRowsMsg{
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Policies"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
@@ -48,40 +62,42 @@ func (m *UserPageModel) fetchUserPolicies(client *data.Client, nextToken *string
 
 func (m *UserPageModel) fetchUserTags(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, _ := client.IAM.GetUserTags(m.Context.(UserPageContext).UserName, nextToken)
-		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Tags"),
-			Rows:   rows,
+		rows, next, err := client.IAM.GetUserTags(m.Context.(UserPageContext).UserName, nextToken)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Tags"),
+				Err:      err,
+				RetryCmd: m.fetchUserTags(client, nextToken),
+			}
 		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchUserTags(client, nextToken)
+		msg := page.NewRowsMsg{
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Tags"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
 }
 
 func (m *UserPageModel) Inspect(client *data.Client) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
+	return page.InspectSelected(m)
+}
+
+func (m *UserPageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
 	if !ok {
-		log.Fatal("This pane is not a table")
+		return "", nil, false
 	}
 
 	switch m.GetCurrentPaneId() {
 	case m.GetPaneId("Policies"):
-		row := table.GetCurrentRowMarshalled()
-		return func() tea.Msg {
-			return page.ChangePageMsg{
-				NewPage:   "iam/policy",
-				FetchData: true,
-				PageContext: PolicyPageContext{
-					UserName:   m.Context.(UserPageContext).UserName,
-					PolicyName: row["Name"],
-					PolicyArn:  row["ARN"],
-				},
-			}
-		}
-	default:
-		return nil
+		return "iam/policy", PolicyPageContext{
+			UserName:   m.Context.(UserPageContext).UserName,
+			PolicyName: row["Name"],
+			PolicyArn:  row["ARN"],
+		}, true
 	}
+	return "", nil, false
 }
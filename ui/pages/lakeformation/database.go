@@ -1,12 +1,9 @@
 package lakeformation
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/page"
-	"github.com/danielcmessias/sawsy/ui/components/table"
 	"github.com/danielcmessias/sawsy/ui/context"
 )
 
@@ -34,7 +31,15 @@ func (m *DatabasePageModel) FetchData(client *data.Client) tea.Cmd {
 
 func (m *DatabasePageModel) fetchDatabaseDetails(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
-		rows, _ := client.LakeFormation.GetDatabaseDetails(m.Context.(DatabasePageContext).DatabaseName)
+		rows, err := client.LakeFormation.GetDatabaseDetails(m.Context.(DatabasePageContext).DatabaseName)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Details"),
+				Err:      err,
+				RetryCmd: m.fetchDatabaseDetails(client),
+			}
+		}
 		msg := page.NewRowsMsg{
 			Page:   m.Spec.Name,
 			PaneId: m.GetPaneId("Details"),
@@ -46,7 +51,15 @@ func (m *DatabasePageModel) fetchDatabaseDetails(client *data.Client) tea.Cmd {
 
 func (m *DatabasePageModel) fetchDatabaseTables(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
-		rows, _ := client.LakeFormation.GetDatabaseTables(m.Context.(DatabasePageContext).DatabaseName)
+		rows, err := client.LakeFormation.GetDatabaseTables(m.Context.(DatabasePageContext).DatabaseName)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Tables"),
+				Err:      err,
+				RetryCmd: m.fetchDatabaseTables(client),
+			}
+		}
 		msg := page.NewRowsMsg{
 			Page:   m.Spec.Name,
 			PaneId: m.GetPaneId("Tables"),
@@ -58,7 +71,15 @@ func (m *DatabasePageModel) fetchDatabaseTables(client *data.Client) tea.Cmd {
 
 func (m *DatabasePageModel) fetchDatabaseTags(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
-		rows, _ := client.LakeFormation.GetDatabaseTags(m.Context.(DatabasePageContext).DatabaseName)
+		rows, err := client.LakeFormation.GetDatabaseTags(m.Context.(DatabasePageContext).DatabaseName)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("LF-Tags"),
+				Err:      err,
+				RetryCmd: m.fetchDatabaseTags(client),
+			}
+		}
 		msg := page.NewRowsMsg{
 			Page:   m.Spec.Name,
 			PaneId: m.GetPaneId("LF-Tags"),
@@ -69,36 +90,21 @@ func (m *DatabasePageModel) fetchDatabaseTags(client *data.Client) tea.Cmd {
 }
 
 func (m *DatabasePageModel) Inspect(client *data.Client) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
-	if !ok {
-		log.Fatal("This pane is not a table")
-	}
+	return page.InspectSelected(m)
+}
 
-	row := table.GetCurrentRowMarshalled()
-	if row == nil {
-		return nil
+func (m *DatabasePageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
+	if !ok {
+		return "", nil, false
 	}
 
-	var nextPage string
-	var pageContext interface{}
-
-	switch m.Tabs.CurrentTabId {
+	switch m.GetCurrentPaneId() {
 	case m.GetPaneId("Tables"):
-		nextPage = "lakeformation/table"
-		pageContext = TablePageContext{
+		return "lakeformation/table", TablePageContext{
 			TableName:    row["Table"],
 			DatabaseName: row["Database"],
-		}
-	default:
-		return nil
-	}
-
-	changePageCmd := func() tea.Msg {
-		return page.ChangePageMsg{
-			NewPage:     nextPage,
-			FetchData:   true,
-			PageContext: pageContext,
-		}
+		}, true
 	}
-	return changePageCmd
+	return "", nil, false
 }
@@ -1,12 +1,9 @@
 package lakeformation
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/page"
-	"github.com/danielcmessias/sawsy/ui/components/table"
 	"github.com/danielcmessias/sawsy/ui/context"
 )
 
@@ -30,20 +27,40 @@ func (m *LakeFormationPageModel) FetchData(client *data.Client) tea.Cmd {
 	)
 }
 
+func (m *LakeFormationPageModel) FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd {
+	switch paneId {
+	case m.GetPaneId("Databases"):
+		return m.fetchDatabasesCmd(client, nextToken)
+	case m.GetPaneId("Tables"):
+		return m.fetchTablesCmd(client, nextToken)
+	case m.GetPaneId("LF-Tags"):
+		return m.fetchLFTagsCmd(client, nextToken)
+	case m.GetPaneId("LF-Tag Perms"):
+		return m.fetchLFTagPermissionsCmd(client, nextToken)
+	case m.GetPaneId("LF Locations"):
+		return m.fetchDataLakeLocationsCmd(client, nextToken)
+	default:
+		return nil
+	}
+}
+
 func (m *LakeFormationPageModel) fetchDatabasesCmd(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.LakeFormation.GetDatabases(nextToken)
+		rows, next, err := client.LakeFormation.GetDatabases(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Databases"),
+				Err:      err,
+				RetryCmd: m.fetchDatabasesCmd(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Databases"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchDatabasesCmd(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Databases"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
@@ -51,18 +68,21 @@ func (m *LakeFormationPageModel) fetchDatabasesCmd(client *data.Client, nextToke
 
 func (m *LakeFormationPageModel) fetchTablesCmd(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.LakeFormation.GetTables(nextToken)
+		rows, next, err := client.LakeFormation.GetTables(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Tables"),
+				Err:      err,
+				RetryCmd: m.fetchTablesCmd(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Tables"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchTablesCmd(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Tables"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
@@ -70,18 +90,21 @@ func (m *LakeFormationPageModel) fetchTablesCmd(client *data.Client, nextToken *
 
 func (m *LakeFormationPageModel) fetchLFTagsCmd(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.LakeFormation.GetLFTags(nextToken)
+		rows, next, err := client.LakeFormation.GetLFTags(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("LF-Tags"),
+				Err:      err,
+				RetryCmd: m.fetchLFTagsCmd(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("LF-Tags"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchLFTagsCmd(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("LF-Tags"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
@@ -89,18 +112,21 @@ func (m *LakeFormationPageModel) fetchLFTagsCmd(client *data.Client, nextToken *
 
 func (m *LakeFormationPageModel) fetchLFTagPermissionsCmd(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.LakeFormation.GetLFTagPermissions(nextToken)
+		rows, next, err := client.LakeFormation.GetLFTagPermissions(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("LF-Tag Perms"),
+				Err:      err,
+				RetryCmd: m.fetchLFTagPermissionsCmd(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("LF-Tag Perms"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchLFTagPermissionsCmd(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("LF-Tag Perms"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
@@ -108,62 +134,44 @@ func (m *LakeFormationPageModel) fetchLFTagPermissionsCmd(client *data.Client, n
 
 func (m *LakeFormationPageModel) fetchDataLakeLocationsCmd(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.LakeFormation.GetDataLakeLocations(nextToken)
+		rows, next, err := client.LakeFormation.GetDataLakeLocations(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("LF Locations"),
+				Err:      err,
+				RetryCmd: m.fetchDataLakeLocationsCmd(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("LF Locations"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchDataLakeLocationsCmd(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("LF Locations"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
 }
 
 func (m *LakeFormationPageModel) Inspect(client *data.Client) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
+	return page.InspectSelected(m)
+}
+
+func (m *LakeFormationPageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
 	if !ok {
-		log.Fatal("This pane is not a table")
+		return "", nil, false
 	}
 
-	row := table.GetCurrentRowMarshalled()
-	var nextPage string
-	var pageContext interface{}
-
-	switch m.Tabs.CurrentTabId {
+	switch m.GetCurrentPaneId() {
 	case m.GetPaneId("Databases"):
-		nextPage = "lakeformation/database"
-		pageContext = DatabasePageContext{
-			DatabaseName: row["Database"],
-		}
+		return "lakeformation/database", DatabasePageContext{DatabaseName: row["Database"]}, true
 	case m.GetPaneId("Tables"):
-		nextPage = "lakeformation/table"
-		pageContext = TablePageContext{
+		return "lakeformation/table", TablePageContext{
 			TableName:    row["Table"],
 			DatabaseName: row["Database"],
-		}
-	case m.GetPaneId("LF-Tags"):
-		// Not implemented
-		return nil
-	case m.GetPaneId("LF-Tag Perms"):
-		// Not implemented
-		return nil
-	case m.GetPaneId("LF Locations"):
-		// Not implemented
-		return nil
-	}
-
-	changePageCmd := func() tea.Msg {
-		return page.ChangePageMsg{
-			NewPage:     nextPage,
-			FetchData:   true,
-			PageContext: pageContext,
-		}
+		}, true
 	}
-	return changePageCmd
+	return "", nil, false
 }
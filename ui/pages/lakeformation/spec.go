@@ -4,6 +4,7 @@ import (
 	"github.com/danielcmessias/sawsy/ui/components/page"
 	"github.com/danielcmessias/sawsy/ui/components/pane"
 	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/utils"
 	"github.com/danielcmessias/sawsy/utils/icons"
 )
 
@@ -23,7 +24,8 @@ var lakeFormationPageSpec = page.PageSpec{
 					Title: "CatalogId",
 				},
 				{
-					Title: "Description",
+					Title:    "Description",
+					MaxWidth: utils.IntPtr(50),
 				},
 				{
 					Title: "S3 Path",
@@ -52,7 +54,8 @@ var lakeFormationPageSpec = page.PageSpec{
 					Title: "CatalogId",
 				},
 				{
-					Title: "Description",
+					Title:    "Description",
+					MaxWidth: utils.IntPtr(50),
 				},
 				{
 					Title: "S3 Path",
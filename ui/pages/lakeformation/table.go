@@ -32,7 +32,15 @@ func (m *TablePageModel) FetchData(client *data.Client) tea.Cmd {
 func (m *TablePageModel) fetchTableDetailsAndSchema(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
 		ctx := m.Context.(TablePageContext)
-		detailsRows, schemaRows, _ := client.LakeFormation.GetTableDetailsAndSchema(ctx.TableName, ctx.DatabaseName)
+		detailsRows, schemaRows, err := client.LakeFormation.GetTableDetailsAndSchema(ctx.TableName, ctx.DatabaseName)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Details"),
+				Err:      err,
+				RetryCmd: m.fetchTableDetailsAndSchema(client),
+			}
+		}
 
 		detailsRowsMsg := page.NewRowsMsg{
 			Page:   m.Spec.Name,
@@ -54,7 +62,15 @@ func (m *TablePageModel) fetchTableDetailsAndSchema(client *data.Client) tea.Cmd
 func (m *TablePageModel) fetchTableTags(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
 		ctx := m.Context.(TablePageContext)
-		rows, _ := client.LakeFormation.GetTableTags(ctx.TableName, ctx.DatabaseName)
+		rows, err := client.LakeFormation.GetTableTags(ctx.TableName, ctx.DatabaseName)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("LF-Tags"),
+				Err:      err,
+				RetryCmd: m.fetchTableTags(client),
+			}
+		}
 		msg := page.NewRowsMsg{
 			Page:   m.Spec.Name,
 			PaneId: m.GetPaneId("LF-Tags"),
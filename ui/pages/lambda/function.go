@@ -26,16 +26,22 @@ func NewFunctionPage(ctx *context.ProgramContext) *FunctionPageModel {
 func (m *FunctionPageModel) FetchData(client *data.Client) tea.Cmd {
 	cmds := []tea.Cmd{
 		m.fetchDetails(client),
-	}
-	for i, met := range metrics {
-		cmds = append(cmds, m.fetchMetric(client, i, met.APIName, met.Statistic, met.Formatter))
+		m.fetchMetrics(client),
 	}
 	return tea.Batch(cmds...)
 }
 
 func (m *FunctionPageModel) fetchDetails(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
-		rows, _ := client.Lambda.GetFunctionDetails(m.Context.(FunctionPageContext).FunctionName)
+		rows, err := client.Lambda.GetFunctionDetails(m.Context.(FunctionPageContext).FunctionName)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Details"),
+				Err:      err,
+				RetryCmd: m.fetchDetails(client),
+			}
+		}
 
 		msg := page.NewRowsMsg{
 			Page:   m.Spec.Name,
@@ -46,10 +52,26 @@ func (m *FunctionPageModel) fetchDetails(client *data.Client) tea.Cmd {
 	}
 }
 
+// The charts share a pane, so they're retried together when one fails
+func (m *FunctionPageModel) fetchMetrics(client *data.Client) tea.Cmd {
+	var cmds []tea.Cmd
+	for i, met := range metrics {
+		cmds = append(cmds, m.fetchMetric(client, i, met.APIName, met.Statistic, met.Formatter))
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m *FunctionPageModel) fetchMetric(client *data.Client, galleryPaneId int, metric string, statistic types.Statistic, valueFormatter func(float64) float64) tea.Cmd {
 	return func() tea.Msg {
-		print("test")
-		data, _ := client.Lambda.GetMetric("sherlock-decryptor-production", metric, statistic)
+		data, err := client.Lambda.GetMetric(m.Context.(FunctionPageContext).FunctionName, metric, statistic)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Monitoring"),
+				Err:      err,
+				RetryCmd: m.fetchMetrics(client),
+			}
+		}
 
 		if valueFormatter != nil {
 			for i, d := range data {
@@ -1,12 +1,9 @@
 package lambda
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/page"
-	"github.com/danielcmessias/sawsy/ui/components/table"
 	"github.com/danielcmessias/sawsy/ui/context"
 )
 
@@ -26,50 +23,50 @@ func (m *LambdaPageModel) FetchData(client *data.Client) tea.Cmd {
 	)
 }
 
+func (m *LambdaPageModel) FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd {
+	switch paneId {
+	case m.GetPaneId("Functions"):
+		return m.fetchFunctions(client, nextToken)
+	default:
+		return nil
+	}
+}
+
 func (m *LambdaPageModel) fetchFunctions(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.Lambda.GetFunctions(nextToken)
+		rows, next, err := client.Lambda.GetFunctions(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Functions"),
+				Err:      err,
+				RetryCmd: m.fetchFunctions(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Functions"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchFunctions(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Functions"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
 }
 
 func (m *LambdaPageModel) Inspect(client *data.Client) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
+	return page.InspectSelected(m)
+}
+
+func (m *LambdaPageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
 	if !ok {
-		log.Fatal("This pane is not a table")
+		return "", nil, false
 	}
-	row := table.GetCurrentRowMarshalled()
-	var nextPage string
-	var pageContext interface{}
 
-	switch m.Tabs.CurrentTabId {
+	switch m.GetCurrentPaneId() {
 	case m.GetPaneId("Functions"):
-		nextPage = "lambda/function"
-		pageContext = FunctionPageContext{
-			FunctionName: row["Name"],
-		}
-	default:
-		return nil
-	}
-
-	changePageCmd := func() tea.Msg {
-		return page.ChangePageMsg{
-			NewPage:     nextPage,
-			FetchData:   true,
-			PageContext: pageContext,
-		}
+		return "lambda/function", FunctionPageContext{FunctionName: row["Name"]}, true
 	}
-	return changePageCmd
+	return "", nil, false
 }
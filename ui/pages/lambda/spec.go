@@ -7,6 +7,7 @@ import (
 	"github.com/danielcmessias/sawsy/ui/components/page"
 	"github.com/danielcmessias/sawsy/ui/components/pane"
 	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/utils"
 	"github.com/danielcmessias/sawsy/utils/icons"
 )
 
@@ -29,7 +30,8 @@ var lambdaPageSpec = page.PageSpec{
 					Title: "Last Modified",
 				},
 				{
-					Title: "Description",
+					Title:    "Description",
+					MaxWidth: utils.IntPtr(50),
 				},
 				{
 					Title: "ARN",
@@ -0,0 +1,115 @@
+package profiles
+
+import (
+	"log"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/data"
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/ui/context"
+)
+
+// Asks the UI to rebuild the data client for a different profile and/or region. An empty Region
+// means the profile's own default region.
+type SwitchProfileMsg struct {
+	Profile string
+	Region  string
+}
+
+type ProfilesPageModel struct {
+	page.Model
+	ctx *context.ProgramContext
+}
+
+func NewProfilesPage(ctx *context.ProgramContext) *ProfilesPageModel {
+	return &ProfilesPageModel{
+		Model: page.New(ctx, profilesPageSpec),
+		ctx:   ctx,
+	}
+}
+
+func (m *ProfilesPageModel) FetchData(client *data.Client) tea.Cmd {
+	return tea.Batch(
+		m.fetchProfiles(),
+		m.fetchRegions(),
+	)
+}
+
+func (m *ProfilesPageModel) fetchProfiles() tea.Cmd {
+	return func() tea.Msg {
+		profiles, err := data.ListProfiles()
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Profiles"),
+				Err:      err,
+				RetryCmd: m.fetchProfiles(),
+			}
+		}
+
+		var rows []table.Row
+		for _, p := range profiles {
+			rows = append(rows, table.Row{p, activeMarker(p == m.ctx.AwsProfile)})
+		}
+
+		msg := page.NewRowsMsg{
+			Page:   m.Spec.Name,
+			PaneId: m.GetPaneId("Profiles"),
+			Rows:   rows,
+		}
+		return msg
+	}
+}
+
+func (m *ProfilesPageModel) fetchRegions() tea.Cmd {
+	return func() tea.Msg {
+		var rows []table.Row
+		for _, r := range data.REGIONS {
+			rows = append(rows, table.Row{r, activeMarker(r == m.ctx.AwsRegion)})
+		}
+
+		msg := page.NewRowsMsg{
+			Page:   m.Spec.Name,
+			PaneId: m.GetPaneId("Regions"),
+			Rows:   rows,
+		}
+		return msg
+	}
+}
+
+func (m *ProfilesPageModel) Inspect(client *data.Client) tea.Cmd {
+	table, ok := m.CurrentPane().(*table.Model)
+	if !ok {
+		log.Fatal("This pane is not a table")
+	}
+
+	row := table.GetCurrentRowMarshalled()
+	if row == nil {
+		return nil
+	}
+
+	var msg SwitchProfileMsg
+	switch m.Tabs.CurrentTabId {
+	case m.GetPaneId("Profiles"):
+		msg = SwitchProfileMsg{
+			Profile: row["Name"],
+		}
+	case m.GetPaneId("Regions"):
+		msg = SwitchProfileMsg{
+			Profile: m.ctx.AwsProfile,
+			Region:  row["Region"],
+		}
+	}
+
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+func activeMarker(active bool) string {
+	if active {
+		return "*"
+	}
+	return ""
+}
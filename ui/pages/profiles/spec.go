@@ -0,0 +1,42 @@
+package profiles
+
+import (
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/pane"
+	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/utils/icons"
+)
+
+var profilesPageSpec = page.PageSpec{
+	Name: "profiles",
+	PaneSpecs: []pane.PaneSpec{
+		table.TableSpec{
+			BaseSpec: pane.BaseSpec{
+				Name: "Profiles",
+				Icon: icons.USER_CIRCLE,
+			},
+			Columns: []table.Column{
+				{
+					Title: "Name",
+				},
+				{
+					Title: "Active",
+				},
+			},
+		},
+		table.TableSpec{
+			BaseSpec: pane.BaseSpec{
+				Name: "Regions",
+				Icon: icons.LOCATION,
+			},
+			Columns: []table.Column{
+				{
+					Title: "Region",
+				},
+				{
+					Title: "Active",
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,167 @@
+package raw
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/data"
+	"github.com/danielcmessias/sawsy/ui/components/code"
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/context"
+	"gopkg.in/yaml.v3"
+)
+
+// Shows the API object a row was made from, with everything the table leaves out
+type RawPageModel struct {
+	page.Model
+}
+
+type RawPageContext struct {
+	Object interface{}
+}
+
+func NewRawPage(ctx *context.ProgramContext) *RawPageModel {
+	return &RawPageModel{
+		Model: page.New(ctx, rawPageSpec),
+	}
+}
+
+// Nothing is fetched, the object is already at hand
+func (m *RawPageModel) FetchData(client *data.Client) tea.Cmd {
+	c, ok := m.Context.(RawPageContext)
+	if !ok {
+		return m.fail(errors.New("no row to show, open this page from a row of a table"))
+	}
+	jsonText, yamlText, err := Marshal(c.Object)
+	if err != nil {
+		return m.fail(err)
+	}
+
+	return tea.Batch(
+		m.setContent("JSON", jsonText, ".json"),
+		m.setContent("YAML", yamlText, ".yaml"),
+	)
+}
+
+func (m *RawPageModel) fail(err error) tea.Cmd {
+	return func() tea.Msg {
+		return page.FetchErrorMsg{
+			Page:   m.Spec.Name,
+			PaneId: m.GetPaneId("JSON"),
+			Err:    err,
+		}
+	}
+}
+
+func (m *RawPageModel) setContent(paneName string, content string, filepath string) tea.Cmd {
+	return func() tea.Msg {
+		return code.NewCodeContentMsg{
+			Page:     m.Spec.Name,
+			PaneId:   m.GetPaneId(paneName),
+			Content:  content,
+			Filepath: filepath,
+		}
+	}
+}
+
+// The object as indented JSON and as YAML, in the order of its fields. Fields that aren't set, and
+// the request metadata the SDK adds to responses, are left out.
+func Marshal(object interface{}) (string, string, error) {
+	b, err := json.Marshal(object)
+	if err != nil {
+		return "", "", fmt.Errorf("error marshalling object: %w", err)
+	}
+	// Decoding into a node rather than a map keeps the fields in order
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return "", "", fmt.Errorf("error converting object: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return "null\n", "null\n", nil
+	}
+	root := doc.Content[0]
+	prune(root)
+
+	var jsonText strings.Builder
+	writeJson(&jsonText, root, "")
+	jsonText.WriteString("\n")
+
+	var yamlText strings.Builder
+	enc := yaml.NewEncoder(&yamlText)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return "", "", fmt.Errorf("error converting object to YAML: %w", err)
+	}
+	return jsonText.String(), yamlText.String(), nil
+}
+
+// Drops unset fields and response metadata, and the JSON styling YAML would otherwise keep
+func prune(n *yaml.Node) {
+	n.Style = 0
+	if n.Kind == yaml.MappingNode {
+		var content []*yaml.Node
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			// Enums that aren't set are empty strings
+			unset := v.Tag == "!!null" || (v.Tag == "!!str" && v.Value == "")
+			if unset || k.Value == "ResultMetadata" {
+				continue
+			}
+			content = append(content, k, v)
+		}
+		n.Content = content
+	}
+	for _, c := range n.Content {
+		prune(c)
+	}
+}
+
+func writeJson(b *strings.Builder, n *yaml.Node, indent string) {
+	inner := indent + "  "
+	switch n.Kind {
+	case yaml.MappingNode:
+		if len(n.Content) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{\n")
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				b.WriteString(",\n")
+			}
+			b.WriteString(inner)
+			writeString(b, n.Content[i].Value)
+			b.WriteString(": ")
+			writeJson(b, n.Content[i+1], inner)
+		}
+		b.WriteString("\n" + indent + "}")
+	case yaml.SequenceNode:
+		if len(n.Content) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for i, c := range n.Content {
+			if i > 0 {
+				b.WriteString(",\n")
+			}
+			b.WriteString(inner)
+			writeJson(b, c, inner)
+		}
+		b.WriteString("\n" + indent + "]")
+	default:
+		if n.Tag == "!!str" {
+			writeString(b, n.Value)
+		} else {
+			b.WriteString(n.Value)
+		}
+	}
+}
+
+func writeString(b *strings.Builder, s string) {
+	quoted, _ := json.Marshal(s)
+	b.Write(quoted)
+}
@@ -0,0 +1,26 @@
+package raw
+
+import (
+	"github.com/danielcmessias/sawsy/ui/components/code"
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/pane"
+	"github.com/danielcmessias/sawsy/utils/icons"
+)
+
+var rawPageSpec = page.PageSpec{
+	Name: "raw",
+	PaneSpecs: []pane.PaneSpec{
+		code.CodeSpec{
+			BaseSpec: pane.BaseSpec{
+				Name: "JSON",
+				Icon: icons.FILE_CODE,
+			},
+		},
+		code.CodeSpec{
+			BaseSpec: pane.BaseSpec{
+				Name: "YAML",
+				Icon: icons.FILE_CODE,
+			},
+		},
+	},
+}
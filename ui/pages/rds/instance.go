@@ -26,17 +26,22 @@ func (m *InstancePageModel) FetchData(client *data.Client) tea.Cmd {
 	cmds := []tea.Cmd{
 		m.fetchDetails(client),
 		m.fetchTags(client),
-	}
-
-	for i, met := range metrics {
-		cmds = append(cmds, m.fetchMetric(client, i, met.APIName, met.Formatter))
+		m.fetchMetrics(client),
 	}
 	return tea.Batch(cmds...)
 }
 
 func (m *InstancePageModel) fetchDetails(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
-		rows, _ := client.RDS.GetInstanceDetails(m.Context.(InstancePageContext).InstanceId)
+		rows, err := client.RDS.GetInstanceDetails(m.Context.(InstancePageContext).InstanceId)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Details"),
+				Err:      err,
+				RetryCmd: m.fetchDetails(client),
+			}
+		}
 
 		msg := page.NewRowsMsg{
 			Page:   m.Spec.Name,
@@ -49,7 +54,15 @@ func (m *InstancePageModel) fetchDetails(client *data.Client) tea.Cmd {
 
 func (m *InstancePageModel) fetchTags(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
-		rows, _ := client.RDS.GetInstanceTags(m.Context.(InstancePageContext).InstanceId)
+		rows, err := client.RDS.GetInstanceTags(m.Context.(InstancePageContext).InstanceId)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Tags"),
+				Err:      err,
+				RetryCmd: m.fetchTags(client),
+			}
+		}
 
 		msg := page.NewRowsMsg{
 			Page:   m.Spec.Name,
@@ -60,9 +73,26 @@ func (m *InstancePageModel) fetchTags(client *data.Client) tea.Cmd {
 	}
 }
 
+// The charts share a pane, so they're retried together when one fails
+func (m *InstancePageModel) fetchMetrics(client *data.Client) tea.Cmd {
+	var cmds []tea.Cmd
+	for i, met := range metrics {
+		cmds = append(cmds, m.fetchMetric(client, i, met.APIName, met.Formatter))
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m *InstancePageModel) fetchMetric(client *data.Client, galleryPaneId int, metric string, valueFormatter func(float64) float64) tea.Cmd {
 	return func() tea.Msg {
-		data, _ := client.RDS.GetMetric(m.Context.(InstancePageContext).InstanceId, metric)
+		data, err := client.RDS.GetMetric(m.Context.(InstancePageContext).InstanceId, metric)
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Monitoring"),
+				Err:      err,
+				RetryCmd: m.fetchMetrics(client),
+			}
+		}
 
 		if valueFormatter != nil {
 			for i, d := range data {
@@ -1,12 +1,9 @@
 package rds
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/page"
-	"github.com/danielcmessias/sawsy/ui/components/table"
 	"github.com/danielcmessias/sawsy/ui/context"
 )
 
@@ -26,49 +23,50 @@ func (m *RDSPageModel) FetchData(client *data.Client) tea.Cmd {
 	)
 }
 
+func (m *RDSPageModel) FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd {
+	switch paneId {
+	case m.GetPaneId("Databases"):
+		return m.fetchDatabases(client, nextToken)
+	default:
+		return nil
+	}
+}
+
 func (m *RDSPageModel) fetchDatabases(client *data.Client, nextToken *string) tea.Cmd {
 	return func() tea.Msg {
-		rows, nextToken, err := client.RDS.GetDBInstances(nextToken)
+		rows, next, err := client.RDS.GetDBInstances(nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Databases"),
+				Err:      err,
+				RetryCmd: m.fetchDatabases(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Databases"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchDatabases(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Databases"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
 }
 
 func (m *RDSPageModel) Inspect(client *data.Client) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
+	return page.InspectSelected(m)
+}
+
+func (m *RDSPageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
 	if !ok {
-		log.Fatal("This pane is not a table")
+		return "", nil, false
 	}
 
-	row := table.GetCurrentRowMarshalled()
-	var nextPage string
-	var pageContext interface{}
-
-	switch m.Tabs.CurrentTabId {
+	switch m.GetCurrentPaneId() {
 	case m.GetPaneId("Databases"):
-		nextPage = "rds/instance"
-		pageContext = InstancePageContext{
-			InstanceId: row["Identifier"],
-		}
-	}
-
-	changePageCmd := func() tea.Msg {
-		return page.ChangePageMsg{
-			NewPage:     nextPage,
-			FetchData:   true,
-			PageContext: pageContext,
-		}
+		return "rds/instance", InstancePageContext{InstanceId: row["Identifier"]}, true
 	}
-	return changePageCmd
+	return "", nil, false
 }
@@ -2,17 +2,14 @@ package s3
 
 import (
 	"fmt"
-	"log"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/code"
-	"github.com/danielcmessias/sawsy/ui/components/help"
 	"github.com/danielcmessias/sawsy/ui/components/page"
 	"github.com/danielcmessias/sawsy/ui/components/table"
-	"github.com/danielcmessias/sawsy/ui/components/tabs"
 	"github.com/danielcmessias/sawsy/ui/context"
 	"github.com/danielcmessias/sawsy/utils/icons"
 )
@@ -42,15 +39,13 @@ func (m *BucketPageModel) View() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		m.Tabs.View(),
-		m.CurrentPane().View(),
+		m.CurrentPaneView(),
 		breadcrumb,
 	)
 }
 
 func (m *BucketPageModel) SetSize(width int, height int) {
-	for _, p := range m.Panes {
-		p.SetSize(width, height-tabs.TabsHeight-help.HelpHeight-breadcrumbHeight)
-	}
+	m.Model.SetSize(width, height-breadcrumbHeight)
 }
 
 func (m *BucketPageModel) FetchData(client *data.Client) tea.Cmd {
@@ -61,21 +56,33 @@ func (m *BucketPageModel) FetchData(client *data.Client) tea.Cmd {
 	)
 }
 
+func (m *BucketPageModel) FetchNextPage(client *data.Client, paneId int, nextToken *string) tea.Cmd {
+	switch paneId {
+	case m.GetPaneId("Objects"):
+		return m.fetchObjects(client, nextToken)
+	default:
+		return nil
+	}
+}
+
 func (m *BucketPageModel) fetchObjects(client *data.Client, nextToken *string) tea.Cmd {
 	context := m.Context.(BucketPageContext)
 	return func() tea.Msg {
-		rows, nextToken, err := client.S3.GetObjects(context.Bucket, context.Region, context.Prefix, nextToken)
+		rows, next, err := client.S3.GetObjects(context.Bucket, context.Region, context.Prefix, nextToken)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Objects"),
+				Err:      err,
+				RetryCmd: m.fetchObjects(client, nextToken),
+			}
 		}
 
 		msg := page.NewRowsMsg{
-			Page:   m.Spec.Name,
-			PaneId: m.GetPaneId("Objects"),
-			Rows:   rows,
-		}
-		if nextToken != nil {
-			msg.NextCmd = m.fetchObjects(client, nextToken)
+			Page:      m.Spec.Name,
+			PaneId:    m.GetPaneId("Objects"),
+			Rows:      rows,
+			NextToken: next,
 		}
 		return msg
 	}
@@ -106,7 +113,12 @@ func (m *BucketPageModel) fetchBucketTags(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
 		rows, err := client.S3.GetBucketTags(context.Bucket, context.Region)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Tags"),
+				Err:      err,
+				RetryCmd: m.fetchBucketTags(client),
+			}
 		}
 
 		msg := page.NewRowsMsg{
@@ -119,48 +131,36 @@ func (m *BucketPageModel) fetchBucketTags(client *data.Client) tea.Cmd {
 }
 
 func (m *BucketPageModel) Inspect(client *data.Client) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
+	cmd := page.InspectSelected(m)
+	if cmd != nil {
+		m.CurrentPane().(*table.Model).ResetCurrentItem()
+	}
+	return cmd
+}
+
+func (m *BucketPageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
 	if !ok {
-		log.Fatal("This pane is not a table")
+		return "", nil, false
 	}
 
 	context := m.Context.(BucketPageContext)
-	row := table.GetCurrentRowMarshalled()
-	if row == nil {
-		return nil
-	}
 	key := row["Key"]
 
 	sanitizedPrefix := context.Prefix + key
 	sanitizedPrefix = strings.Replace(sanitizedPrefix, fmt.Sprintf("%s ", icons.FILE), "", 1)
 	sanitizedPrefix = strings.Replace(sanitizedPrefix, fmt.Sprintf("%s ", icons.FOLDER), "", 1)
 
-	var nextPage string
-	var nextContext interface{}
 	if strings.HasPrefix(key, icons.FILE) {
-		nextPage = "s3/object"
-		nextContext = ObjectPageContext{
+		return "s3/object", ObjectPageContext{
 			Bucket: context.Bucket,
 			Key:    sanitizedPrefix,
 			Region: context.Region,
-		}
-	} else {
-		nextPage = "s3/objects"
-		nextContext = BucketPageContext{
-			Bucket: context.Bucket,
-			Region: context.Region,
-			Prefix: sanitizedPrefix,
-		}
+		}, true
 	}
-
-	changePageCmd := func() tea.Msg {
-		return page.ChangePageMsg{
-			NewPage:     nextPage,
-			FetchData:   true,
-			PageContext: nextContext,
-		}
-	}
-
-	table.ResetCurrentItem()
-	return changePageCmd
+	return "s3/objects", BucketPageContext{
+		Bucket: context.Bucket,
+		Region: context.Region,
+		Prefix: sanitizedPrefix,
+	}, true
 }
@@ -1,8 +1,6 @@
 package s3
 
 import (
-	"log"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/danielcmessias/sawsy/data"
 	"github.com/danielcmessias/sawsy/ui/components/page"
@@ -36,7 +34,12 @@ func (m *ObjectPageModel) fetchProperties(client *data.Client) tea.Cmd {
 	return func() tea.Msg {
 		rows, err := client.S3.GetObjectProperties(context.Bucket, context.Key, context.Region)
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Properties"),
+				Err:      err,
+				RetryCmd: m.fetchProperties(client),
+			}
 		}
 
 		msg := page.NewRowsMsg{
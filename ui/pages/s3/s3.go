@@ -10,6 +10,9 @@ import (
 	"github.com/danielcmessias/sawsy/ui/context"
 )
 
+// Shown in place of a bucket's region when it can't be looked up, e.g. for lack of permission
+const unknownRegion = "?"
+
 type S3PageModel struct {
 	page.Model
 }
@@ -32,7 +35,12 @@ func (m *S3PageModel) fetchBuckets(client *data.Client) tea.Cmd {
 
 		rows, err := client.S3.GetBuckets()
 		if err != nil {
-			log.Fatal(err)
+			return page.FetchErrorMsg{
+				Page:     m.Spec.Name,
+				PaneId:   m.GetPaneId("Buckets"),
+				Err:      err,
+				RetryCmd: m.fetchBuckets(client),
+			}
 		}
 
 		for _, row := range rows {
@@ -50,57 +58,51 @@ func (m *S3PageModel) fetchBuckets(client *data.Client) tea.Cmd {
 }
 
 func (m *S3PageModel) fetchBucketRegion(client *data.Client, row table.Row) tea.Cmd {
-	table, ok := m.CurrentPane().(*table.Model)
+	bucketsTable, ok := m.CurrentPane().(*table.Model)
 	if !ok {
 		log.Fatal("This pane is not a table")
 	}
 
 	return func() tea.Msg {
-		region, err := client.S3.GetBucketRegion(row[0])
+		// One bucket that can't be looked up shouldn't hide the rest behind an error
+		region, err := client.S3.GetBucketRegion(table.RawText(row[0]))
 		if err != nil {
-			log.Fatal(err)
+			region = unknownRegion
 		}
 
-		marshalledRow := table.MarhsalRow(row)
-		marshalledRow["Region"] = region
+		updatedRow := append(table.Row{}, row...)
+		updatedRow[bucketsTable.ColumnIndex("Region")] = region
 
 		return page.UpdateRowMsg{
 			Page:            m.Spec.Name,
 			PaneId:          m.GetPaneId("Buckets"),
-			Row:             table.UnmarshalRow(marshalledRow),
+			Row:             updatedRow,
 			PrimaryKeyIndex: 0,
 		}
 	}
 }
 
 func (m *S3PageModel) Inspect(client *data.Client) tea.Cmd {
-	if m.Tabs.CurrentTabId != m.GetPaneId("Buckets") {
-		return nil
-	}
+	return page.InspectSelected(m)
+}
 
-	table, ok := m.CurrentPane().(*table.Model)
+func (m *S3PageModel) InspectTarget() (string, interface{}, bool) {
+	row, ok := m.CurrentRow()
 	if !ok {
-		log.Fatal("This pane is not a table")
-	}
-
-	row := table.GetCurrentRowMarshalled()
-
-	// If the region hasn't been found yet, we need to wait.
-	// An alternative idea might be to just fetch the region in GetObjects if it's missing at that
-	// point
-	if row["Region"] == data.LOADING_ALIAS {
-		return nil
+		return "", nil, false
 	}
 
-	changePageCmd := func() tea.Msg {
-		return page.ChangePageMsg{
-			NewPage:   "s3/objects",
-			FetchData: true,
-			PageContext: BucketPageContext{
-				Bucket: row["Name"],
-				Region: row["Region"],
-			},
+	switch m.GetCurrentPaneId() {
+	case m.GetPaneId("Buckets"):
+		region := row["Region"]
+		// Not found (yet), the objects page looks it up itself
+		if region == data.LOADING_ALIAS || region == unknownRegion {
+			region = ""
 		}
+		return "s3/objects", BucketPageContext{
+			Bucket: row["Name"],
+			Region: region,
+		}, true
 	}
-	return changePageCmd
+	return "", nil, false
 }
@@ -57,7 +57,7 @@ func (m *ServicesPageModel) Inspect(client *data.Client) tea.Cmd {
 		log.Fatal("This pane is not a table")
 	}
 
-	nextPage := services[table.GetCurrentRow()[0]]
+	nextPage := services[table.GetCurrentRowMarshalled()["Name"]]
 
 	return func() tea.Msg {
 		return page.ChangePageMsg{
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/palette"
+	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/ui/components/toast"
+	"github.com/danielcmessias/sawsy/ui/links"
+	"github.com/danielcmessias/sawsy/ui/pages/bookmarks"
+)
+
+// Things to do from the palette other than going somewhere
+type paletteCommand string
+
+const (
+	refreshCommand paletteCommand = "refresh"
+	exportCommand  paletteCommand = "export"
+	profileCommand paletteCommand = "switch profile"
+)
+
+// How many recently visited resources the palette offers
+const maxRecent = 20
+
+// Remembers a visit to a resource for the palette, most recent first
+func (m *Model) addRecent(pageName string, context interface{}) {
+	link := links.Link{Page: pageName, Context: context}
+	title, ok := links.Format(link)
+	if context == nil || !ok {
+		return
+	}
+
+	recent := []links.Link{link}
+	for _, l := range m.recent {
+		if t, _ := links.Format(l); t != title {
+			recent = append(recent, l)
+		}
+	}
+	if len(recent) > maxRecent {
+		recent = recent[:maxRecent]
+	}
+	m.recent = recent
+}
+
+// Commands, bookmarks, recent resources, then every page that doesn't need a resource to show
+func (m *Model) paletteItems() []palette.Item {
+	var items []palette.Item
+	for _, c := range []paletteCommand{refreshCommand, exportCommand, profileCommand} {
+		items = append(items, palette.Item{Title: string(c), Kind: "command", Value: c})
+	}
+
+	for _, b := range m.ctx.Config.Bookmarks {
+		if link, err := bookmarks.Resolve(b); err == nil {
+			items = append(items, palette.Item{Title: b.Name, Kind: "bookmark", Value: link})
+		}
+	}
+
+	for _, l := range m.recent {
+		title, _ := links.Format(l)
+		items = append(items, palette.Item{Title: title, Kind: "recent", Value: l})
+	}
+
+	var pageNames []string
+	for name := range m.pages {
+		// Reached with the switch profile command, and from a table row
+		if name != "profiles" && name != "raw" && !links.IsResourcePage(name) {
+			pageNames = append(pageNames, name)
+		}
+	}
+	sort.Strings(pageNames)
+	for _, name := range pageNames {
+		items = append(items, palette.Item{Title: name, Kind: "page", Value: links.Link{Page: name}})
+	}
+	return items
+}
+
+func (m *Model) onPaletteSubmit(msg palette.SubmitMsg) tea.Cmd {
+	// Nothing matched, but it might still be somewhere to go, e.g. an ARN
+	if msg.Item == nil {
+		if msg.Value == "" {
+			return nil
+		}
+		return m.goTo(msg.Value)
+	}
+
+	switch value := msg.Item.Value.(type) {
+	case links.Link:
+		return func() tea.Msg {
+			return page.ChangePageMsg{
+				NewPage:     value.Page,
+				PageContext: value.Context,
+				FetchData:   true,
+			}
+		}
+
+	case paletteCommand:
+		switch value {
+		case refreshCommand:
+			return m.refresh()
+		case exportCommand:
+			return m.startExport()
+		case profileCommand:
+			if m.currentPage == "profiles" {
+				return nil
+			}
+			return func() tea.Msg {
+				return page.ChangePageMsg{
+					NewPage:   "profiles",
+					FetchData: true,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Model) startExport() tea.Cmd {
+	p := m.getCurrentPage()
+	t, ok := p.GetPaneAt(p.GetCurrentPaneId()).(*table.Model)
+	if !ok {
+		return toast.ShowError("Only tables can be exported")
+	}
+	return t.StartExport()
+}
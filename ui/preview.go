@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danielcmessias/sawsy/data"
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/ui/components/tabs"
+	"github.com/danielcmessias/sawsy/ui/links"
+	"github.com/danielcmessias/sawsy/ui/styles"
+	"github.com/danielcmessias/sawsy/utils"
+)
+
+// How long the cursor has to rest on a row before its preview is fetched, so that scrolling
+// through a list doesn't fetch every row on the way
+const previewDelay = 300 * time.Millisecond
+
+var (
+	previewStyle lipgloss.Style
+	// Takes the place of the tabs, lining the preview up with the list
+	previewTitleStyle lipgloss.Style
+)
+
+func init() {
+	styles.OnThemeChange(func() {
+		previewStyle = lipgloss.NewStyle().
+			BorderLeft(true).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(styles.Theme.Border)
+
+		previewTitleStyle = lipgloss.NewStyle().
+			Height(tabs.TabsHeight).
+			PaddingTop(1).
+			PaddingLeft(2).
+			Foreground(styles.Theme.PageMetaText)
+	})
+}
+
+// The page the selected row opens, shown next to the list it's in
+type preview struct {
+	open bool
+	// Copies of the pages kept apart from the ones navigated to, so previews never clobber them
+	pages    map[string]page.Page
+	pageName string
+	context  interface{}
+
+	id     int // Changes with the previewed row, anything fetched for an older one is dropped
+	cancel context.CancelFunc
+	// What the preview is fetched with, cancelled with it. Kept apart from the UI's client, which is
+	// replaced when the profile or region is switched.
+	client *data.Client
+}
+
+type previewTickMsg struct {
+	id int
+}
+
+// A message returned by a command fetching a preview
+type previewMsg struct {
+	id  int
+	msg tea.Msg
+}
+
+func (m *Model) togglePreview() tea.Cmd {
+	m.preview.open = !m.preview.open
+	if !m.preview.open {
+		m.setPreview("", nil)
+	}
+	m.layout()
+	return m.syncPreview()
+}
+
+// Whether the preview is open and the current page has rows to preview
+func (m *Model) previewShown() bool {
+	_, ok := m.getCurrentPage().(page.Previewable)
+	return m.preview.open && ok
+}
+
+// Sizes the current page, and the preview next to it if it's shown
+func (m *Model) layout() {
+	if !m.previewShown() {
+		m.getCurrentPage().SetSize(m.ctx.ScreenWidth, m.ctx.ScreenHeight)
+		return
+	}
+	m.getCurrentPage().SetSize(m.ctx.ScreenWidth/2, m.ctx.ScreenHeight)
+	if p, ok := m.preview.pages[m.preview.pageName]; ok {
+		p.SetSize(m.previewWidth(), m.ctx.ScreenHeight)
+	}
+}
+
+// The rest of the screen next to the list, less the border between them
+func (m *Model) previewWidth() int {
+	return m.ctx.ScreenWidth - m.ctx.ScreenWidth/2 - 1
+}
+
+// Follows the selected row, waiting for it to settle before fetching its page
+func (m *Model) syncPreview() tea.Cmd {
+	if !m.previewShown() {
+		if m.preview.pageName != "" {
+			m.setPreview("", nil)
+		}
+		return nil
+	}
+
+	pageName, context, _ := m.getCurrentPage().(page.Previewable).InspectTarget()
+	if pageName == m.preview.pageName && reflect.DeepEqual(context, m.preview.context) {
+		return nil
+	}
+	m.setPreview(pageName, context)
+	if pageName == "" {
+		return nil
+	}
+
+	p := m.preview.pages[pageName]
+	p.ClearData()
+	p.SetPageContext(context)
+	m.layout()
+
+	id := m.preview.id
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return previewTickMsg{id: id}
+	})
+}
+
+// Switches the preview to another page, cancelling whatever was being fetched for the last one
+func (m *Model) setPreview(pageName string, context interface{}) {
+	if m.preview.cancel != nil {
+		m.preview.cancel()
+		m.preview.cancel = nil
+		m.preview.client = nil
+	}
+	m.preview.id++
+	m.preview.pageName = pageName
+	m.preview.context = context
+}
+
+func (m *Model) fetchPreview(msg previewTickMsg) tea.Cmd {
+	p, ok := m.preview.pages[m.preview.pageName]
+	if msg.id != m.preview.id || !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.preview.cancel = cancel
+	m.preview.client = m.client.WithContext(ctx)
+	return m.withPreview(p.FetchData(m.preview.client))
+}
+
+// Tags the message returned by cmd with the current preview
+func (m *Model) withPreview(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	id := m.preview.id
+	return func() tea.Msg {
+		return previewMsg{
+			id:  id,
+			msg: cmd(),
+		}
+	}
+}
+
+func (m *Model) onPreviewMsg(msg previewMsg) tea.Cmd {
+	p, ok := m.preview.pages[m.preview.pageName]
+	if msg.id != m.preview.id || !ok {
+		return nil
+	}
+
+	if cmds, ok := utils.Unbatch(msg.msg); ok {
+		for i, c := range cmds {
+			cmds[i] = m.withPreview(c)
+		}
+		return tea.Batch(cmds...)
+	}
+
+	var cmds []tea.Cmd
+	switch msg := msg.msg.(type) {
+	case page.NewRowsMsg:
+		cmds = append(cmds, m.parsePreviewRowsMsg(p, msg))
+
+	case page.BatchedNewRowsMsg:
+		for _, _msg := range msg.Msgs {
+			cmds = append(cmds, m.parsePreviewRowsMsg(p, _msg))
+		}
+
+	case page.UpdateRowMsg:
+		if t, ok := p.GetPaneAt(msg.PaneId).(*table.Model); ok {
+			t.UpdateRow(msg.PrimaryKeyIndex, msg.Row)
+		}
+
+	case page.FetchErrorMsg:
+		p.SetError(msg)
+
+	case nil:
+
+	default:
+		// Code and charts
+		cmd, _ := p.Update(m.preview.client, msg)
+		cmds = append(cmds, m.withPreview(cmd))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Only the first page of rows is shown, there's no scrolling a preview to load more
+func (m *Model) parsePreviewRowsMsg(p page.Page, msg page.NewRowsMsg) tea.Cmd {
+	if msg.Page != m.preview.pageName {
+		return nil
+	}
+	if msg.Overwrite {
+		p.ClearRows(msg.PaneId)
+	}
+	p.AppendRows(msg.PaneId, msg.Rows)
+	return m.withPreview(msg.NextCmd)
+}
+
+func (m *Model) previewView() string {
+	p, ok := m.preview.pages[m.preview.pageName]
+	if !m.previewShown() || !ok {
+		return ""
+	}
+	title, ok := links.Format(links.Link{Page: m.preview.pageName, Context: m.preview.context})
+	if !ok {
+		title = m.preview.pageName
+	}
+	width := m.previewWidth()
+	return previewStyle.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		previewTitleStyle.Copy().Width(width).MaxWidth(width).Render(title),
+		p.CurrentPaneView(),
+	))
+}
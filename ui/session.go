@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/ui/links"
+)
+
+// How many of the pages visited are kept for going back to in the next session
+const maxSessionHistory = 50
+
+// Where the UI was left, per profile and account, so the next launch can pick up from there. Pages
+// are kept as links, written the way the go to prompt reads them.
+type session struct {
+	Page    string               `json:"page"`
+	History []string             `json:"history,omitempty"`
+	Pages   map[string]pageState `json:"pages,omitempty"`
+}
+
+type pageState struct {
+	Tab int `json:"tab,omitempty"`
+	// Keyed by pane name
+	Tables map[string]tableState `json:"tables,omitempty"`
+}
+
+type tableState struct {
+	Column string `json:"column,omitempty"`
+	Search string `json:"search,omitempty"`
+}
+
+// Go has no os.UserStateDir, so this follows the XDG spec where there is one
+func stateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "sawsy"), nil
+	}
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "sawsy"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "sawsy"), nil
+}
+
+func sessionPath(profile string, awsAccountId string) (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	name := url.PathEscape(awsAccountId) + "_" + url.PathEscape(profile) + ".json"
+	return filepath.Join(dir, "sessions", name), nil
+}
+
+func readSession(profile string, awsAccountId string) (session, error) {
+	var s session
+	path, err := sessionPath(profile, awsAccountId)
+	if err != nil {
+		return s, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+func writeSession(profile string, awsAccountId string, s session) error {
+	path, err := sessionPath(profile, awsAccountId)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Where the session left off, and the pages visited before it. Links that no longer parse are
+// skipped.
+func (s session) links() (links.Link, []PageVisit, bool) {
+	var visits []PageVisit
+	for _, l := range append(s.History, s.Page) {
+		link, err := links.ParseString(l)
+		if err != nil {
+			continue
+		}
+		visits = append(visits, PageVisit{PageName: link.Page, Context: link.Context})
+	}
+	if len(visits) == 0 {
+		return links.Link{}, nil, false
+	}
+	last := visits[len(visits)-1]
+	return links.Link{Page: last.PageName, Context: last.Context}, visits[:len(visits)-1], true
+}
+
+// Saves where the UI is for the current profile and account. Pages that can't be written as links,
+// like the raw view of a row, are left out.
+func (m *Model) saveSession() error {
+	visits := append(append([]PageVisit{}, m.visitedPages...), PageVisit{
+		PageName: m.currentPage,
+		Context:  m.getCurrentPage().GetPageContext(),
+	})
+	var formatted []string
+	for _, v := range visits {
+		// The profile picker is only ever passed through
+		if v.PageName == "profiles" {
+			continue
+		}
+		if l, ok := links.Format(links.Link{Page: v.PageName, Context: v.Context}); ok {
+			formatted = append(formatted, l)
+		}
+	}
+	if len(formatted) == 0 {
+		formatted = []string{"services"}
+	}
+	if len(formatted) > maxSessionHistory+1 {
+		formatted = formatted[len(formatted)-maxSessionHistory-1:]
+	}
+
+	s := session{
+		Page:    formatted[len(formatted)-1],
+		History: formatted[:len(formatted)-1],
+		Pages:   map[string]pageState{},
+	}
+	for name, p := range m.pages {
+		state := pageState{Tab: p.GetCurrentPaneId(), Tables: map[string]tableState{}}
+		for i, spec := range p.GetSpec().PaneSpecs {
+			t, ok := p.GetPaneAt(i).(*table.Model)
+			if !ok {
+				continue
+			}
+			column, search := t.Selection()
+			// The first column with no search is where every table starts anyway
+			if search != "" || t.ColumnIndex(column) > 0 {
+				state.Tables[spec.GetName()] = tableState{Column: column, Search: search}
+			}
+		}
+		if state.Tab != 0 || len(state.Tables) > 0 {
+			s.Pages[name] = state
+		}
+	}
+	return writeSession(m.ctx.AwsProfile, m.ctx.AwsAccountId, s)
+}
+
+// Puts each page back on the tab it was left on, with the same columns selected and searches typed
+func (m *Model) restorePages(s session) {
+	for name, state := range s.Pages {
+		p, ok := m.pages[name]
+		if !ok {
+			continue
+		}
+		p.SetCurrentPaneId(state.Tab)
+		for i, spec := range p.GetSpec().PaneSpecs {
+			t, ok := p.GetPaneAt(i).(*table.Model)
+			if !ok {
+				continue
+			}
+			if ts, ok := state.Tables[spec.GetName()]; ok {
+				t.RestoreSelection(ts.Column, ts.Search)
+			}
+		}
+	}
+}
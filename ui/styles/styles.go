@@ -3,14 +3,22 @@ package styles
 import "github.com/charmbracelet/lipgloss"
 
 var (
-	MainTextStyle = lipgloss.NewStyle().
+	MainTextStyle lipgloss.Style
+
+	FooterHeight = 3
+	FooterStyle  lipgloss.Style
+)
+
+func init() {
+	OnThemeChange(func() {
+		MainTextStyle = lipgloss.NewStyle().
 			Foreground(Theme.MainText).
 			Bold(true)
 
-	FooterHeight = 3
-	FooterStyle  = lipgloss.NewStyle().
+		FooterStyle = lipgloss.NewStyle().
 			Height(FooterHeight - 1).
 			BorderTop(true).
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(Theme.Border)
-)
+	})
+}
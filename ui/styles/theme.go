@@ -1,6 +1,18 @@
 package styles
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/styles"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/danielcmessias/sawsy/config"
+)
 
 type ThemeSpec struct {
 	MainText        lipgloss.AdaptiveColor
@@ -12,6 +24,9 @@ type ThemeSpec struct {
 	Border          lipgloss.AdaptiveColor
 	FaintBorder     lipgloss.AdaptiveColor
 	SearchPrompt    lipgloss.AdaptiveColor
+	ErrorText       lipgloss.AdaptiveColor
+	// How code is highlighted, see CodeStyleConfig
+	CodeStyle string
 }
 
 var dracula = ThemeSpec{
@@ -24,19 +39,44 @@ var dracula = ThemeSpec{
 	Border:          lipgloss.AdaptiveColor{Light: "#44475a", Dark: "#44475a"},
 	FaintBorder:     lipgloss.AdaptiveColor{Light: "#2b2b40", Dark: "#2b2b40"},
 	SearchPrompt:    lipgloss.AdaptiveColor{Light: "#50fa7b", Dark: "#50fa7b"},
+	ErrorText:       lipgloss.AdaptiveColor{Light: "#ff5555", Dark: "#ff5555"},
+	CodeStyle:       "dracula",
 }
 
-// Light is latte, dark is ???
+// Mocha
 var catppuccin = ThemeSpec{
-	MainText:        lipgloss.AdaptiveColor{Light: "#4c4f69", Dark: "#0fff00"},
-	FaintText:       lipgloss.AdaptiveColor{Light: "#6c6f85", Dark: "#6272a4"},
-	PageMetaText:    lipgloss.AdaptiveColor{Light: "#df8e1d", Dark: "#f1fa8c"},
-	HighlightTab:    lipgloss.AdaptiveColor{Light: "#d20f39	", Dark: "#bd93f9"},
-	HighlightRow:    lipgloss.AdaptiveColor{Light: "#e64553	", Dark: "#ff79c6"},
-	HighlightColumn: lipgloss.AdaptiveColor{Light: "#209fb5", Dark: "#50fa7b"},
-	Border:          lipgloss.AdaptiveColor{Light: "#dce0e8", Dark: "#44475a"},
-	FaintBorder:     lipgloss.AdaptiveColor{Light: "#e6e9ef", Dark: "#2b2b40"},
-	SearchPrompt:    lipgloss.AdaptiveColor{Light: "#7287fd", Dark: "#50fa7b"},
+	MainText:        lipgloss.AdaptiveColor{Light: "#cdd6f4", Dark: "#cdd6f4"},
+	FaintText:       lipgloss.AdaptiveColor{Light: "#7f849c", Dark: "#7f849c"},
+	PageMetaText:    lipgloss.AdaptiveColor{Light: "#f9e2af", Dark: "#f9e2af"},
+	HighlightTab:    lipgloss.AdaptiveColor{Light: "#cba6f7", Dark: "#cba6f7"},
+	HighlightRow:    lipgloss.AdaptiveColor{Light: "#f5c2e7", Dark: "#f5c2e7"},
+	HighlightColumn: lipgloss.AdaptiveColor{Light: "#a6e3a1", Dark: "#a6e3a1"},
+	Border:          lipgloss.AdaptiveColor{Light: "#45475a", Dark: "#45475a"},
+	FaintBorder:     lipgloss.AdaptiveColor{Light: "#313244", Dark: "#313244"},
+	SearchPrompt:    lipgloss.AdaptiveColor{Light: "#89dceb", Dark: "#89dceb"},
+	ErrorText:       lipgloss.AdaptiveColor{Light: "#f38ba8", Dark: "#f38ba8"},
+	CodeStyle:       "dark",
+}
+
+// For terminals with a light background
+var catppuccinLatte = ThemeSpec{
+	MainText:        lipgloss.AdaptiveColor{Light: "#4c4f69", Dark: "#4c4f69"},
+	FaintText:       lipgloss.AdaptiveColor{Light: "#8c8fa1", Dark: "#8c8fa1"},
+	PageMetaText:    lipgloss.AdaptiveColor{Light: "#df8e1d", Dark: "#df8e1d"},
+	HighlightTab:    lipgloss.AdaptiveColor{Light: "#8839ef", Dark: "#8839ef"},
+	HighlightRow:    lipgloss.AdaptiveColor{Light: "#e64553", Dark: "#e64553"},
+	HighlightColumn: lipgloss.AdaptiveColor{Light: "#209fb5", Dark: "#209fb5"},
+	Border:          lipgloss.AdaptiveColor{Light: "#bcc0cc", Dark: "#bcc0cc"},
+	FaintBorder:     lipgloss.AdaptiveColor{Light: "#dce0e8", Dark: "#dce0e8"},
+	SearchPrompt:    lipgloss.AdaptiveColor{Light: "#7287fd", Dark: "#7287fd"},
+	ErrorText:       lipgloss.AdaptiveColor{Light: "#d20f39", Dark: "#d20f39"},
+	CodeStyle:       "light",
+}
+
+var themes = map[string]ThemeSpec{
+	"dracula":          dracula,
+	"catppuccin":       catppuccin,
+	"catppuccin-latte": catppuccinLatte,
 }
 
 var (
@@ -44,7 +84,135 @@ var (
 	MainContentPadding = 1
 )
 
-var Theme = func() ThemeSpec {
-	return dracula
-	// return catppuccin
-}()
+// The theme in use. Packages deriving styles from it do so in OnThemeChange.
+var Theme = dracula
+
+var themeListeners []func()
+
+// Runs f now and again whenever the theme changes, to derive styles from the theme
+func OnThemeChange(f func()) {
+	themeListeners = append(themeListeners, f)
+	f()
+}
+
+func SetTheme(theme ThemeSpec) {
+	Theme = theme
+	for _, f := range themeListeners {
+		f()
+	}
+}
+
+func ThemeNames() []string {
+	var names []string
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// The theme set in the config, a built-in one or a theme file, with any changes the config makes
+func LoadTheme(c config.ThemeConfig) (ThemeSpec, error) {
+	name := c.Name
+	var file config.ThemeFile
+	if config.IsThemeFile(name) {
+		var err error
+		if file, err = config.ReadThemeFile(name); err != nil {
+			return dracula, err
+		}
+		name = file.Base
+	}
+
+	theme, err := builtInTheme(name)
+	if err != nil {
+		return dracula, err
+	}
+	if err := theme.apply(file.CodeStyle, file.Colors); err != nil {
+		return dracula, fmt.Errorf("error in theme %s: %w", c.Name, err)
+	}
+	if err := theme.apply(c.CodeStyle, c.Colors); err != nil {
+		return dracula, fmt.Errorf("error in theme config: %w", err)
+	}
+	return theme, nil
+}
+
+func builtInTheme(name string) (ThemeSpec, error) {
+	if name == "" {
+		return dracula, nil
+	}
+	theme, ok := themes[name]
+	if !ok {
+		return dracula, fmt.Errorf("unknown theme %q, expected the path of a theme file or one of: %s", name, strings.Join(ThemeNames(), ", "))
+	}
+	return theme, nil
+}
+
+// Keyed as in the config
+func (t *ThemeSpec) colors() map[string]*lipgloss.AdaptiveColor {
+	return map[string]*lipgloss.AdaptiveColor{
+		"mainText":        &t.MainText,
+		"faintText":       &t.FaintText,
+		"pageMetaText":    &t.PageMetaText,
+		"highlightTab":    &t.HighlightTab,
+		"highlightRow":    &t.HighlightRow,
+		"highlightColumn": &t.HighlightColumn,
+		"border":          &t.Border,
+		"faintBorder":     &t.FaintBorder,
+		"searchPrompt":    &t.SearchPrompt,
+		"errorText":       &t.ErrorText,
+	}
+}
+
+func (t *ThemeSpec) apply(codeStyle string, colors map[string]config.ThemeColor) error {
+	if codeStyle != "" {
+		if _, ok := CodeStyleConfig(codeStyle); !ok {
+			return fmt.Errorf("unknown code style %q", codeStyle)
+		}
+		t.CodeStyle = codeStyle
+	}
+
+	fields := t.colors()
+	for name, c := range colors {
+		field, ok := fields[name]
+		if !ok {
+			var names []string
+			for name := range fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("unknown color %q, expected one of: %s", name, strings.Join(names, ", "))
+		}
+		for _, value := range []string{c.Light, c.Dark} {
+			if !isColor(value) {
+				return fmt.Errorf("%s: %q isn't a hex color or ANSI color number", name, value)
+			}
+		}
+		*field = lipgloss.AdaptiveColor{Light: c.Light, Dark: c.Dark}
+	}
+	return nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+func isColor(s string) bool {
+	if hexColor.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// How glamour renders code in a style, which is one of glamour's own (e.g. dracula, dark or light)
+// or else a chroma style (e.g. monokai or github)
+func CodeStyleConfig(name string) (ansi.StyleConfig, bool) {
+	if st, ok := glamour.DefaultStyles[name]; ok {
+		return *st, true
+	}
+	if _, ok := styles.Registry[name]; !ok {
+		return ansi.StyleConfig{}, false
+	}
+	st := glamour.DarkStyleConfig
+	st.CodeBlock.Chroma = nil
+	st.CodeBlock.Theme = name
+	return st, true
+}
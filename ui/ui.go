@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,28 +15,47 @@ import (
 
 	"github.com/danielcmessias/sawsy/ui/components/help"
 	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/palette"
+	"github.com/danielcmessias/sawsy/ui/components/prompt"
 	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/ui/components/toast"
 	"github.com/danielcmessias/sawsy/ui/context"
+	"github.com/danielcmessias/sawsy/ui/links"
+	"github.com/danielcmessias/sawsy/ui/pages/bookmarks"
 	"github.com/danielcmessias/sawsy/ui/pages/glue"
 	"github.com/danielcmessias/sawsy/ui/pages/iam"
 	"github.com/danielcmessias/sawsy/ui/pages/lakeformation"
 	"github.com/danielcmessias/sawsy/ui/pages/lambda"
+	"github.com/danielcmessias/sawsy/ui/pages/profiles"
+	"github.com/danielcmessias/sawsy/ui/pages/raw"
 	"github.com/danielcmessias/sawsy/ui/pages/rds"
 	"github.com/danielcmessias/sawsy/ui/pages/s3"
 	"github.com/danielcmessias/sawsy/ui/pages/services"
+	"github.com/danielcmessias/sawsy/ui/styles"
 	"github.com/danielcmessias/sawsy/utils"
+	"github.com/danielcmessias/sawsy/utils/browser"
+	"github.com/danielcmessias/sawsy/utils/clipboard"
 )
 
 type Model struct {
-	config config.Config
-	client *data.Client
-	ctx    *context.ProgramContext
-	help   help.Model
-	keys   utils.KeyMap
+	config        config.Config
+	clientOptions data.ClientOptions
+	client        *data.Client
+	ctx           *context.ProgramContext
+	help          help.Model
+	toast         toast.Model
+	prompt        prompt.Model
+	palette       palette.Model
+	keys          utils.KeyMap
 
 	pages        map[string]page.Page
 	currentPage  string
 	visitedPages []PageVisit
+	recent       []links.Link
+
+	visit            visit
+	preview          preview
+	interruptedPages map[string]bool
 }
 
 type PageVisit struct {
@@ -41,8 +63,30 @@ type PageVisit struct {
 	Context  interface{}
 }
 
-func NewModel(config config.Config, firstPage string) (Model, error) {
-	client, err := data.NewClient()
+// Opens firstPage, or if it has no page wherever the last session for the profile and account left
+// off. Unless fresh, tabs, selected columns and searches are also put back as they were left.
+func NewModel(config config.Config, clientOptions data.ClientOptions, firstPage links.Link, fresh bool) (Model, error) {
+	// Tables fall back to the default format, so say what's wrong with it here
+	if _, err := table.NewCellFormat(config.Format); err != nil {
+		return Model{}, err
+	}
+	remaps := make(map[string][]string, len(config.Keys))
+	for name, keys := range config.Keys {
+		remaps[name] = keys
+	}
+	keys, err := utils.NewKeyMap(remaps)
+	if err != nil {
+		return Model{}, err
+	}
+
+	// Components take their styles from the theme as they're made
+	theme, err := styles.LoadTheme(config.Theme)
+	if err != nil {
+		return Model{}, err
+	}
+	styles.SetTheme(theme)
+
+	client, err := data.NewClient(clientOptions)
 	if err != nil {
 		return Model{}, fmt.Errorf("error creating new data client: %w", err)
 	}
@@ -52,15 +96,75 @@ func NewModel(config config.Config, firstPage string) (Model, error) {
 		return Model{}, err
 	}
 
+	var lastSession session
+	if !fresh {
+		// A missing or unreadable session is as good as a fresh start
+		lastSession, _ = readSession(client.GetProfile(), awsAccountId)
+	}
+	var history []PageVisit
+	if firstPage.Page == "" {
+		firstPage = links.Link{Page: "services"}
+		if link, visits, ok := lastSession.links(); ok {
+			firstPage, history = link, visits
+		}
+	}
+
 	ctx := &context.ProgramContext{
 		Config:       &config,
 		AwsAccountId: awsAccountId,
-		AwsService:   firstPage,
-		Keys:         utils.Keys,
+		AwsProfile:   client.GetProfile(),
+		AwsRegion:    client.GetRegion(),
+		AwsService:   firstPage.Page,
+		Keys:         keys,
 	}
 
-	var allPages = []page.Page{
+	pages := map[string]page.Page{}
+	for _, p := range NewPages(ctx) {
+		pages[p.GetSpec().Name] = p
+	}
+	previewPages := map[string]page.Page{}
+	for _, p := range NewPages(ctx) {
+		previewPages[p.GetSpec().Name] = p
+	}
+
+	m := Model{
+		config:           config,
+		clientOptions:    clientOptions,
+		client:           client,
+		ctx:              ctx,
+		help:             help.NewModel(ctx),
+		toast:            toast.NewModel(ctx),
+		prompt:           prompt.NewModel(ctx),
+		palette:          palette.NewModel(ctx),
+		keys:             keys,
+		pages:            pages,
+		currentPage:      firstPage.Page,
+		interruptedPages: map[string]bool{},
+		preview:          preview{pages: previewPages},
+	}
+	if _, ok := pages[firstPage.Page]; !ok {
+		return Model{}, fmt.Errorf("no page named %s", firstPage.Page)
+	}
+	for _, visit := range history {
+		if _, ok := pages[visit.PageName]; !ok {
+			continue
+		}
+		m.visitedPages = append(m.visitedPages, visit)
+		// Going back doesn't usually fetch the page again, but nothing has been loaded for these
+		m.interruptedPages[visit.PageName] = true
+	}
+	m.restorePages(lastSession)
+	m.getCurrentPage().SetPageContext(firstPage.Context)
+	m.addRecent(firstPage.Page, firstPage.Context)
+	m.startVisit()
+	return m, nil
+}
+
+// Creates every page of the program
+func NewPages(ctx *context.ProgramContext) []page.Page {
+	return []page.Page{
 		services.NewServicesPage(ctx),
+		profiles.NewProfilesPage(ctx),
 		glue.NewGluePage(ctx),
 		glue.NewJobsPage(ctx),
 		iam.NewIAMPage(ctx),
@@ -77,25 +181,18 @@ func NewModel(config config.Config, firstPage string) (Model, error) {
 		s3.NewS3Page(ctx),
 		s3.NewBucketPage(ctx),
 		s3.NewObjectPage(ctx),
+		raw.NewRawPage(ctx),
+		bookmarks.NewBookmarksPage(ctx),
 	}
-	pages := map[string]page.Page{}
-	for _, p := range allPages {
-		pages[p.GetSpec().Name] = p
-	}
-
-	return Model{
-		config:      config,
-		client:      client,
-		ctx:         ctx,
-		help:        help.NewModel(ctx),
-		keys:        utils.Keys,
-		pages:       pages,
-		currentPage: firstPage,
-	}, nil
 }
 
 type initMsg struct{}
 
+type clientChangedMsg struct {
+	client       *data.Client
+	awsAccountId string
+}
+
 func initScreen() tea.Msg {
 	return initMsg{}
 }
@@ -114,7 +211,39 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
-	cmd, consumed := m.getCurrentPage().Update(m.client, msg)
+	switch msg := msg.(type) {
+	case visitMsg:
+		return m.onVisitMsg(msg)
+	case previewMsg:
+		return m, m.onPreviewMsg(msg)
+	case previewTickMsg:
+		return m, m.fetchPreview(msg)
+	case clipboard.OSC52Msg:
+		if err := msg.Write(os.Stdout); err != nil {
+			return m, toast.ShowError(fmt.Sprintf("Couldn't copy: %v", err))
+		}
+		return m, func() tea.Msg { return msg.Done }
+	}
+
+	if m.prompt.Focused() {
+		var cmd tea.Cmd
+		m.prompt, cmd = m.prompt.Update(msg)
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m, cmd
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	if m.palette.Focused() {
+		var cmd tea.Cmd
+		m.palette, cmd = m.palette.Update(msg)
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m, cmd
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	cmd, consumed := m.getCurrentPage().Update(m.visit.client, msg)
 	cmds = append(cmds, cmd)
 	if consumed {
 		return m, tea.Batch(cmds...)
@@ -124,11 +253,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.keys.Inspect) && !m.ctx.LockKeyboardCapture:
-			cmds = append(cmds, m.getCurrentPage().Inspect(m.client))
+			cmds = append(cmds, m.getCurrentPage().Inspect(m.visit.client))
+
+		case key.Matches(msg, m.keys.ViewRaw) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.viewRaw())
+
+		case key.Matches(msg, m.keys.OpenConsole) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.openInConsole())
 
 		case key.Matches(msg, m.keys.Services) && !m.ctx.LockKeyboardCapture:
 			cmds = append(cmds, m.changePage("services", nil, true))
 
+		case key.Matches(msg, m.keys.Profiles) && !m.ctx.LockKeyboardCapture && m.currentPage != "profiles":
+			cmds = append(cmds, func() tea.Msg {
+				return page.ChangePageMsg{
+					NewPage:   "profiles",
+					FetchData: true,
+				}
+			})
+
+		case key.Matches(msg, m.keys.AddBookmark) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.startBookmark())
+
+		case key.Matches(msg, m.keys.Bookmarks) && !m.ctx.LockKeyboardCapture && m.currentPage != "bookmarks":
+			cmds = append(cmds, func() tea.Msg {
+				return page.ChangePageMsg{
+					NewPage:   "bookmarks",
+					FetchData: true,
+				}
+			})
+
+		case key.Matches(msg, m.keys.GoTo) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.prompt.Open("goto", "Go to: ", "page, page and id, s3://bucket/prefix/ or ARN"))
+
+		case key.Matches(msg, m.keys.TogglePreview) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.togglePreview())
+
+		case key.Matches(msg, m.keys.Palette) && !m.ctx.LockKeyboardCapture:
+			cmds = append(cmds, m.palette.Open(m.paletteItems()))
+
 		case key.Matches(msg, m.keys.PrevPage) && !m.ctx.LockKeyboardCapture:
 			l := len(m.visitedPages)
 			if l == 0 {
@@ -139,11 +302,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, m.changePage(prev.PageName, prev.Context, false))
 
 		case key.Matches(msg, m.keys.Refresh) && !m.ctx.LockKeyboardCapture:
-			m.getCurrentPage().ClearData()
-			cmds = append(cmds, m.getCurrentPage().FetchData(m.client))
+			cmds = append(cmds, m.refresh())
 
 		case key.Matches(msg, m.keys.Quit):
-			if !(m.ctx.LockKeyboardCapture && msg.String() == "q") {
+			// Whatever quit is bound to, letters are typed while something has the keyboard
+			if !(m.ctx.LockKeyboardCapture && msg.Type == tea.KeyRunes) {
+				// Quitting shouldn't fail because the state directory can't be written to
+				_ = m.saveSession()
 				return m, tea.Quit
 			}
 		}
@@ -154,6 +319,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case page.UpdateRowMsg:
 		m.parseUpdateRowMsg(msg)
 
+	case table.LoadMoreMsg:
+		cmds = append(cmds, m.loadMore(msg))
+
+	case page.FetchErrorMsg:
+		if msg.Page == m.visit.pageName {
+			m.visit.failed = true
+			m.visit.markLive(msg.Page, msg.PaneId)
+		}
+		m.pages[msg.Page].SetError(msg)
+
 	case page.BatchedNewRowsMsg:
 		for _, _msg := range msg.Msgs {
 			cmds = append(cmds, m.parseNewRowsMsg(_msg))
@@ -166,25 +341,63 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		})
 		cmds = append(cmds, m.changePage(msg.NewPage, msg.PageContext, msg.FetchData))
 
+	case prompt.SubmitMsg:
+		switch msg.Id {
+		case "goto":
+			cmds = append(cmds, m.goTo(msg.Value))
+		case "bookmark":
+			cmds = append(cmds, m.addBookmark(msg.Value))
+		}
+
+	case palette.SubmitMsg:
+		cmds = append(cmds, m.onPaletteSubmit(msg))
+
+	case profiles.SwitchProfileMsg:
+		cmds = append(cmds, m.switchProfile(msg.Profile, msg.Region))
+
+	case clientChangedMsg:
+		cmds = append(cmds, m.onClientChanged(msg))
+
 	case initMsg:
-		cmds = append(cmds, m.getCurrentPage().FetchData(m.client))
+		cmds = append(cmds, m.fetchCurrentPage())
 
 	case tea.WindowSizeMsg:
 		m.onWindowSizeChanged(msg)
 	}
 
-	var helpCmd tea.Cmd
+	var helpCmd, toastCmd tea.Cmd
 	m.help, helpCmd = m.help.Update(msg)
-	cmds = append(cmds, helpCmd)
+	m.toast, toastCmd = m.toast.Update(msg)
+	cmds = append(cmds, helpCmd, toastCmd)
+
+	// Moving through a table, or its rows arriving, changes the row to preview
+	cmds = append(cmds, m.syncPreview())
 
 	return m, tea.Batch(cmds...)
 }
 
 func (m Model) View() string {
+	pageView := m.getCurrentPage().View()
+	if preview := m.previewView(); preview != "" {
+		pageView = lipgloss.JoinHorizontal(lipgloss.Top, pageView, preview)
+	}
+	footer := m.help.View()
+	if m.palette.Focused() {
+		// The palette grows upwards over the bottom of the page
+		footer = m.palette.View()
+		lines := strings.Split(pageView, "\n")
+		if cut := m.palette.Height() - styles.FooterHeight; cut > 0 && cut < len(lines) {
+			pageView = strings.Join(lines[:len(lines)-cut], "\n")
+		}
+	} else if m.prompt.Focused() {
+		footer = m.prompt.View()
+	} else if m.toast.Visible() {
+		footer = m.toast.View()
+	}
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
-		m.getCurrentPage().View(),
-		m.help.View(),
+		pageView,
+		footer,
 	)
 }
 
@@ -194,6 +407,7 @@ func (m *Model) onWindowSizeChanged(msg tea.WindowSizeMsg) {
 	for _, p := range m.pages {
 		p.SetSize(msg.Width, msg.Height)
 	}
+	m.layout()
 	m.help.SetWidth(msg.Width)
 }
 
@@ -208,17 +422,41 @@ func (m *Model) getCurrentPage() page.Page {
 
 func (m *Model) parseNewRowsMsg(msg page.NewRowsMsg) tea.Cmd {
 	var cmds []tea.Cmd
-	if msg.Overwrite {
+	table, isTable := m.pages[msg.Page].GetPaneAt(msg.PaneId).(*table.Model)
+	// Fresh rows replace any that were shown from the cache
+	if msg.Overwrite || (isTable && table.IsStale()) {
 		m.pages[msg.Page].ClearRows(msg.PaneId)
 	}
+	if msg.Page == m.visit.pageName {
+		m.visit.markLive(msg.Page, msg.PaneId)
+	}
 	m.pages[msg.Page].AppendRows(msg.PaneId, msg.Rows)
-	// Uncomment this to fetch ALL rows
+	if isTable {
+		table.SetNextToken(msg.NextToken)
+	}
 	if msg.NextCmd != nil {
-		cmds = append(cmds, msg.NextCmd)
+		cmds = append(cmds, m.withVisit(msg.NextCmd))
 	}
 	return tea.Batch(cmds...)
 }
 
+// Fetches the next page of rows for a table, as long as it's still the one being looked at
+func (m *Model) loadMore(msg table.LoadMoreMsg) tea.Cmd {
+	p := m.getCurrentPage()
+	paneId := p.GetCurrentPaneId()
+	if p.GetPaneAt(paneId) != msg.Table {
+		msg.Table.CancelLoadMore()
+		return nil
+	}
+
+	cmd := p.FetchNextPage(m.visit.client, paneId, msg.NextToken)
+	if cmd == nil {
+		msg.Table.CancelLoadMore()
+		return nil
+	}
+	return m.withVisit(cmd)
+}
+
 func (m *Model) parseUpdateRowMsg(msg page.UpdateRowMsg) {
 	table, ok := m.pages[msg.Page].GetPaneAt(msg.PaneId).(*table.Model)
 	if !ok {
@@ -234,15 +472,192 @@ func (m *Model) changePage(pageName string, context interface{}, fetchData bool)
 	}
 
 	m.currentPage = pageName
-	m.getCurrentPage().SetSize(m.ctx.ScreenWidth, m.ctx.ScreenHeight)
+	m.startVisit()
 	m.getCurrentPage().SetPageContext(context)
+	m.layout()
+	m.addRecent(pageName, context)
 
 	m.ctx.AwsService = m.getCurrentPage().GetSpec().Name
 
+	// The previous visit to this page was cut short, so what it loaded is incomplete
+	if m.interruptedPages[pageName] {
+		fetchData = true
+	}
+
 	var cmd tea.Cmd
 	if fetchData {
-		m.getCurrentPage().ClearData()
-		cmd = m.getCurrentPage().FetchData(m.client)
+		cmd = m.fetchCurrentPage()
 	}
 	return cmd
 }
+
+func (m *Model) refresh() tea.Cmd {
+	// Only retry the failed pane if there is one, leaving the rest of the page as is
+	if retryCmd := m.getCurrentPage().RetryCurrentPane(); retryCmd != nil {
+		return m.withVisit(retryCmd)
+	}
+	m.startVisit()
+	return m.fetchCurrentPage()
+}
+
+// Clears the current page and fetches its data as part of the current visit. Whatever is in the
+// response cache for the page is shown while the live requests are in flight.
+func (m *Model) fetchCurrentPage() tea.Cmd {
+	delete(m.interruptedPages, m.currentPage)
+	m.getCurrentPage().ClearData()
+
+	cmds := []tea.Cmd{m.withVisit(m.getCurrentPage().FetchData(m.visit.client))}
+	if cacheClient := m.visit.client.FromCache(); cacheClient != nil {
+		cmds = append(cmds, m.withVisitFromCache(m.getCurrentPage().FetchData(cacheClient)))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Opens the API object behind the selected row
+func (m *Model) viewRaw() tea.Cmd {
+	p := m.getCurrentPage()
+	t, ok := p.GetPaneAt(p.GetCurrentPaneId()).(*table.Model)
+	if !ok {
+		return nil
+	}
+	object, ok := t.GetCurrentRow().Object()
+	if !ok {
+		return toast.Show("There's nothing more to show for this row")
+	}
+	return func() tea.Msg {
+		return page.ChangePageMsg{
+			NewPage:     "raw",
+			PageContext: raw.RawPageContext{Object: object},
+			FetchData:   true,
+		}
+	}
+}
+
+// Opens the selected row's resource in the AWS console, or else the current page's
+func (m *Model) openInConsole() tea.Cmd {
+	p := m.getCurrentPage()
+	link := links.Link{Page: m.currentPage, Context: p.GetPageContext()}
+	if previewable, ok := p.(page.Previewable); ok {
+		if pageName, context, ok := previewable.InspectTarget(); ok {
+			link = links.Link{Page: pageName, Context: context}
+		}
+	}
+	url, ok := links.ConsoleURL(link, m.ctx.AwsRegion, m.ctx.AwsAccountId)
+	if !ok {
+		return toast.Show("The AWS console has no page for this")
+	}
+
+	return func() tea.Msg {
+		err := browser.Open(url)
+		switch {
+		case err == nil:
+			return toast.ShowMsg{Message: "Opened in the browser"}
+		case errors.Is(err, browser.ErrNoBrowser):
+			// Shown instead, and copied if the terminal allows it
+			return clipboard.Copy(url, toast.ShowMsg{Message: url})()
+		}
+		return toast.ShowMsg{Message: fmt.Sprintf("Couldn't open the browser: %v", err), IsError: true}
+	}
+}
+
+// Opens the page a link typed into the go to prompt points at
+func (m *Model) goTo(value string) tea.Cmd {
+	link, err := links.ParseString(value)
+	if err == nil {
+		if _, ok := m.pages[link.Page]; !ok {
+			err = fmt.Errorf("no page named %s", link.Page)
+		}
+	}
+	if err != nil {
+		return toast.ShowError(err.Error())
+	}
+
+	return func() tea.Msg {
+		return page.ChangePageMsg{
+			NewPage:     link.Page,
+			PageContext: link.Context,
+			FetchData:   true,
+		}
+	}
+}
+
+// Asks what to call a bookmark of the current page, suggesting its link
+func (m *Model) startBookmark() tea.Cmd {
+	link := links.Link{Page: m.currentPage, Context: m.getCurrentPage().GetPageContext()}
+	bookmark, ok := bookmarks.New("", link)
+	if !ok {
+		return toast.ShowError("This page can't be bookmarked")
+	}
+	return m.prompt.Open("bookmark", "Bookmark as: ", bookmark.Link)
+}
+
+// Bookmarks the current page, named after its link if no name is given
+func (m *Model) addBookmark(name string) tea.Cmd {
+	link := links.Link{Page: m.currentPage, Context: m.getCurrentPage().GetPageContext()}
+	bookmark, ok := bookmarks.New(strings.TrimSpace(name), link)
+	if !ok {
+		return nil
+	}
+	if bookmark.Name == "" {
+		bookmark.Name = bookmark.Link
+	}
+	return bookmarks.Add(m.ctx, bookmark)
+}
+
+func (m *Model) switchProfile(profile string, region string) tea.Cmd {
+	// The new profile has a session of its own, keep where this one was left
+	_ = m.saveSession()
+	options := m.clientOptions
+	options.Profile = profile
+	options.Region = region
+	return connect(options, m.currentPage, m.getCurrentPage().GetCurrentPaneId())
+}
+
+// Makes a client for options, reporting any failure on the pane the switch was made from. Runs
+// outside the update loop, so doesn't touch the model.
+func connect(options data.ClientOptions, pageName string, paneId int) tea.Cmd {
+	return func() tea.Msg {
+		client, err := data.NewClient(options)
+		var awsAccountId string
+		if err == nil {
+			awsAccountId, err = client.GetCurrentAWSAccountId()
+		}
+		if err != nil {
+			return page.FetchErrorMsg{
+				Page:     pageName,
+				PaneId:   paneId,
+				Err:      err,
+				RetryCmd: connect(options, pageName, paneId),
+			}
+		}
+
+		return clientChangedMsg{
+			client:       client,
+			awsAccountId: awsAccountId,
+		}
+	}
+}
+
+func (m *Model) onClientChanged(msg clientChangedMsg) tea.Cmd {
+	// Replaced rather than overwritten, so commands still running with the old one never see a mix
+	// of the two. They're cancelled with their visit or preview below.
+	m.client = msg.client
+	m.ctx.AwsAccountId = msg.awsAccountId
+	m.ctx.AwsProfile = m.client.GetProfile()
+	m.ctx.AwsRegion = m.client.GetRegion()
+
+	for _, p := range m.pages {
+		p.ClearData()
+	}
+	m.setPreview("", nil)
+
+	// Go back to wherever the picker was opened from. The rest of the history belonged to the old
+	// account, so drop it.
+	prev := PageVisit{PageName: "services"}
+	if l := len(m.visitedPages); l > 0 {
+		prev = m.visitedPages[l-1]
+	}
+	m.visitedPages = nil
+
+	return m.changePage(prev.PageName, prev.Context, true)
+}
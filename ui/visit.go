@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/danielcmessias/sawsy/data"
+	"github.com/danielcmessias/sawsy/ui/components/page"
+	"github.com/danielcmessias/sawsy/ui/components/table"
+	"github.com/danielcmessias/sawsy/utils"
+)
+
+// Each time a page is shown (or refreshed) it gets a new visit. Requests made during a visit use
+// its context, which is cancelled as soon as the user moves on, and any messages that still make
+// it back afterwards are dropped.
+type visit struct {
+	id       int
+	pageName string
+	client   *data.Client
+	cancel   context.CancelFunc
+
+	pending int  // Commands started during this visit that haven't returned yet
+	failed  bool // At least one fetch during this visit returned an error
+
+	// Panes that have had a live response, so cached rows arriving late must not replace it
+	live map[string]bool
+}
+
+// A message returned by a command started during a visit
+type visitMsg struct {
+	visitId   int
+	fromCache bool
+	msg       tea.Msg
+}
+
+func (v *visit) markLive(pageName string, paneId int) {
+	v.live[fmt.Sprintf("%s/%d", pageName, paneId)] = true
+}
+
+func (v *visit) isLive(pageName string, paneId int) bool {
+	return v.live[fmt.Sprintf("%s/%d", pageName, paneId)]
+}
+
+// Cancels the current visit and starts a new one for the current page. Pages left before they
+// finished loading are remembered, so that they are fetched again when returned to.
+func (m *Model) startVisit() {
+	if m.visit.cancel != nil {
+		m.visit.cancel()
+		if m.visit.pending > 0 || m.visit.failed {
+			m.interruptedPages[m.visit.pageName] = true
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.visit = visit{
+		id:       m.visit.id + 1,
+		pageName: m.currentPage,
+		client:   m.client.WithContext(ctx),
+		cancel:   cancel,
+		live:     map[string]bool{},
+	}
+}
+
+// Tags the message returned by cmd with the current visit
+func (m *Model) withVisit(cmd tea.Cmd) tea.Cmd {
+	return m.tagVisit(cmd, false)
+}
+
+// Like withVisit, for commands fetching through a client that only reads the response cache
+func (m *Model) withVisitFromCache(cmd tea.Cmd) tea.Cmd {
+	return m.tagVisit(cmd, true)
+}
+
+func (m *Model) tagVisit(cmd tea.Cmd, fromCache bool) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	m.visit.pending++
+	visitId := m.visit.id
+	return func() tea.Msg {
+		return visitMsg{
+			visitId:   visitId,
+			fromCache: fromCache,
+			msg:       cmd(),
+		}
+	}
+}
+
+func (m Model) onVisitMsg(msg visitMsg) (tea.Model, tea.Cmd) {
+	if msg.visitId != m.visit.id {
+		return m, nil
+	}
+	m.visit.pending--
+
+	if cmds, ok := utils.Unbatch(msg.msg); ok {
+		for i, c := range cmds {
+			cmds[i] = m.tagVisit(c, msg.fromCache)
+		}
+		return m, tea.Batch(cmds...)
+	}
+	if msg.msg == nil {
+		return m, nil
+	}
+	if msg.fromCache {
+		return m, m.onCachedMsg(msg.msg)
+	}
+	return m.Update(msg.msg)
+}
+
+// Shows rows read from the cache, marked as stale, unless live ones for the pane already arrived.
+// Everything else from the cache, including the errors for requests it had nothing for, is dropped.
+func (m *Model) onCachedMsg(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+	switch msg := msg.(type) {
+	case page.NewRowsMsg:
+		cmds = append(cmds, m.parseCachedRowsMsg(msg))
+
+	case page.BatchedNewRowsMsg:
+		for _, _msg := range msg.Msgs {
+			cmds = append(cmds, m.parseCachedRowsMsg(_msg))
+		}
+
+	case page.UpdateRowMsg:
+		if !m.visit.isLive(msg.Page, msg.PaneId) {
+			m.parseUpdateRowMsg(msg)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) parseCachedRowsMsg(msg page.NewRowsMsg) tea.Cmd {
+	if m.visit.isLive(msg.Page, msg.PaneId) {
+		return nil
+	}
+	m.pages[msg.Page].AppendRows(msg.PaneId, msg.Rows)
+	// Further pages are left to the live response, which has tokens that are still valid
+	if table, ok := m.pages[msg.Page].GetPaneAt(msg.PaneId).(*table.Model); ok {
+		table.SetStale(true)
+	}
+	return m.withVisitFromCache(msg.NextCmd)
+}
@@ -0,0 +1,51 @@
+package browser
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Returned by Open when there's no browser to open, e.g. over SSH
+var ErrNoBrowser = errors.New("no browser available")
+
+// Opens url in the system's browser, or the one in $BROWSER
+func Open(url string) error {
+	name, args, ok := command()
+	if !ok {
+		return ErrNoBrowser
+	}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return ErrNoBrowser
+	}
+	cmd := exec.Command(path, append(args, url)...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Don't leave a zombie behind, the browser carries on by itself
+	go cmd.Wait()
+	return nil
+}
+
+func command() (string, []string, bool) {
+	if b := os.Getenv("BROWSER"); b != "" {
+		return b, nil, true
+	}
+	// A browser would open on the remote machine
+	if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+		return "", nil, false
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return "open", nil, true
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler"}, true
+	}
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return "", nil, false
+	}
+	return "xdg-open", nil, true
+}
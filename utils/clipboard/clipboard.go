@@ -0,0 +1,51 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Asks the terminal to copy something, with OSC 52. The UI writes it to the terminal from its update
+// loop, then sends Done.
+type OSC52Msg struct {
+	seq  string
+	Done tea.Msg
+}
+
+// Bubble Tea writes each frame to stdout in one call, and an os.File never interleaves two writes, so
+// the sequence lands between two frames rather than in the middle of one
+func (msg OSC52Msg) Write(out io.Writer) error {
+	_, err := io.WriteString(out, msg.seq)
+	return err
+}
+
+// Copies text to the clipboard, then sends done. It's sent to the terminal with OSC 52, which works
+// over SSH and in tmux if the terminal allows it, and written to the system clipboard too when
+// there's a tool for it (pbcopy, xclip, xsel, wl-copy, ...).
+func Copy(text string, done tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		// Over SSH the system clipboard is the remote machine's, which is no use to anyone
+		if !clipboard.Unsupported && os.Getenv("SSH_TTY") == "" {
+			// The terminal may still take it, so this failing isn't worth reporting on its own
+			_ = clipboard.WriteAll(text)
+		}
+		return OSC52Msg{seq: osc52(text), Done: done}
+	}
+}
+
+func osc52(text string) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	switch {
+	// Multiplexers only pass sequences on to the terminal wrapped in one of their own
+	case os.Getenv("TMUX") != "":
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = "\x1bP" + seq + "\x1b\\"
+	}
+	return seq
+}
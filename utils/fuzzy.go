@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Matches s if it has all of query's characters in order, not necessarily next to each other,
+// ignoring case. Returns a score, higher for characters next to each other or at the start of
+// words, and the byte offsets in s of the characters matched.
+func FuzzyMatch(query string, s string) (int, []int, bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+
+	score, qi := 0, 0
+	var positions []int
+	prevMatched := false
+	var prev rune
+	for i, r := range s {
+		if qi == len(q) {
+			break
+		}
+		if unicode.ToLower(r) != q[qi] {
+			prevMatched = false
+			prev = r
+			continue
+		}
+		score++
+		if prevMatched {
+			score += 2
+		}
+		if i == 0 || isWordSeparator(prev) {
+			score += 3
+		}
+		positions = append(positions, i)
+		prevMatched = true
+		prev = r
+		qi++
+	}
+	return score, positions, qi == len(q)
+}
+
+func isWordSeparator(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("/-_:.", r)
+}
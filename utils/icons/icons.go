@@ -3,6 +3,7 @@ package icons
 const (
 	APPLICATION = "ﬓ"
 	AWS         = ""
+	BOOKMARK    = ""
 	BUCKET      = ""
 	BUG         = ""
 	CHART       = ""
@@ -1,22 +1,53 @@
 package utils
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 type KeyMap struct {
 	Up            key.Binding
 	Down          key.Binding
 	FirstLine     key.Binding
 	LastLine      key.Binding
+	LoadMore      key.Binding
 	TogglePreview key.Binding
 	Refresh       key.Binding
 	NextTab       key.Binding
 	PrevTab       key.Binding
 	NextCol       key.Binding
 	PrevCol       key.Binding
+	MoveColLeft   key.Binding
+	MoveColRight  key.Binding
+	HideCol       key.Binding
+	ResetCols     key.Binding
 	StartSearch   key.Binding
 	EndSearch     key.Binding
+	NextMatch     key.Binding
+	PrevMatch     key.Binding
+	ViewRaw       key.Binding
+	Mark          key.Binding
+	MarkAll       key.Binding
+	InvertMarks   key.Binding
+	Sort          key.Binding
+	Export        key.Binding
+	CopyCell      key.Binding
+	CopyRows      key.Binding
+	CopyJSON      key.Binding
 	Inspect       key.Binding
 	Services      key.Binding
+	OpenConsole   key.Binding
+	Profiles      key.Binding
+	AddBookmark   key.Binding
+	Bookmarks     key.Binding
+	Unbookmark    key.Binding
+	GoTo          key.Binding
+	Palette       key.Binding
 	PrevPage      key.Binding
 	Help          key.Binding
 	Quit          key.Binding
@@ -29,15 +60,24 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down},
+		{k.FirstLine, k.LastLine, k.LoadMore},
 		{k.PrevCol, k.NextCol},
+		{k.MoveColLeft, k.MoveColRight, k.HideCol, k.ResetCols},
 		{k.PrevTab, k.NextTab},
-		{k.Inspect, k.PrevPage},
-		{k.StartSearch, k.Services},
+		{k.Inspect, k.PrevPage, k.GoTo, k.Palette},
+		{k.Mark, k.MarkAll, k.InvertMarks},
+		{k.StartSearch, k.NextMatch, k.PrevMatch},
+		{k.Sort, k.Export},
+		{k.CopyCell, k.CopyRows, k.CopyJSON},
+		{k.ViewRaw, k.OpenConsole, k.Services},
+		{k.Refresh, k.Profiles, k.TogglePreview},
+		{k.AddBookmark, k.Bookmarks, k.Unbookmark},
 		{k.Help, k.Quit},
 	}
 }
 
-var Keys = KeyMap{
+// The bindings before any are changed in the config
+var DefaultKeys = KeyMap{
 	Up: key.NewBinding(
 		key.WithKeys("up", "k"),
 		key.WithHelp("↑/k", "move up"),
@@ -62,6 +102,22 @@ var Keys = KeyMap{
 		key.WithKeys("left", "h"),
 		key.WithHelp("/h", "previous col"),
 	),
+	MoveColLeft: key.NewBinding(
+		key.WithKeys("<"),
+		key.WithHelp("<", "move col left"),
+	),
+	MoveColRight: key.NewBinding(
+		key.WithKeys(">"),
+		key.WithHelp(">", "move col right"),
+	),
+	HideCol: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "hide col"),
+	),
+	ResetCols: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "show all cols"),
+	),
 	StartSearch: key.NewBinding(
 		key.WithKeys("/"),
 		key.WithHelp("/", "search"),
@@ -69,6 +125,50 @@ var Keys = KeyMap{
 	EndSearch: key.NewBinding(
 		key.WithKeys("esc", "enter"),
 	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "prev match"),
+	),
+	ViewRaw: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "view raw"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "mark row"),
+	),
+	MarkAll: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "mark all/none"),
+	),
+	InvertMarks: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "invert marks"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "sort by column"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "export table"),
+	),
+	CopyCell: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy"),
+	),
+	CopyRows: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy rows"),
+	),
+	CopyJSON: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "copy rows as JSON"),
+	),
 	Inspect: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "inspect"),
@@ -77,6 +177,34 @@ var Keys = KeyMap{
 		key.WithKeys("s"),
 		key.WithHelp("s", "services"),
 	),
+	OpenConsole: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "open in AWS console"),
+	),
+	Profiles: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "profile/region"),
+	),
+	AddBookmark: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "bookmark page"),
+	),
+	Bookmarks: key.NewBinding(
+		key.WithKeys("'"),
+		key.WithHelp("'", "bookmarks"),
+	),
+	Unbookmark: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "delete bookmark"),
+	),
+	GoTo: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "go to"),
+	),
+	Palette: key.NewBinding(
+		key.WithKeys(":"),
+		key.WithHelp(":", "command palette"),
+	),
 	PrevPage: key.NewBinding(
 		key.WithKeys("backspace"),
 		key.WithHelp("backspace", "prev page"),
@@ -89,9 +217,13 @@ var Keys = KeyMap{
 		key.WithKeys("G", "end"),
 		key.WithHelp("G/end", "last item"),
 	),
+	LoadMore: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "load more"),
+	),
 	TogglePreview: key.NewBinding(
 		key.WithKeys("p"),
-		key.WithHelp("p", "open in Preview"),
+		key.WithHelp("p", "toggle preview"),
 	),
 	Refresh: key.NewBinding(
 		key.WithKeys("r"),
@@ -106,3 +238,108 @@ var Keys = KeyMap{
 		key.WithHelp("q", "quit"),
 	),
 }
+
+// Bindings only used while typing into a search or prompt, so they don't clash with the others
+var inputBindings = map[string]bool{
+	"endSearch": true,
+}
+
+var keyNames = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+	" ":     "space",
+}
+
+// The default bindings with the keys of some replaced, keyed by binding as in the config (e.g.
+// quit or nextTab). Errors if a binding doesn't exist or a key is bound twice.
+func NewKeyMap(remaps map[string][]string) (KeyMap, error) {
+	keys := DefaultKeys
+	bindings := keys.bindings()
+
+	var unknown []string
+	for name, remap := range remaps {
+		b, ok := bindings[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		b.SetKeys(remap...)
+		b.SetHelp(helpKeys(remap), b.Help().Desc)
+	}
+	if len(unknown) > 0 {
+		var names []string
+		for name := range bindings {
+			names = append(names, name)
+		}
+		sort.Strings(unknown)
+		sort.Strings(names)
+		return DefaultKeys, fmt.Errorf(
+			"unknown key bindings %s, expected some of: %s",
+			strings.Join(unknown, ", "),
+			strings.Join(names, ", "),
+		)
+	}
+
+	if err := keys.checkConflicts(); err != nil {
+		return DefaultKeys, err
+	}
+	return keys, nil
+}
+
+// Every binding, keyed by its field name starting in lower case
+func (k *KeyMap) bindings() map[string]*key.Binding {
+	bindings := make(map[string]*key.Binding)
+	v := reflect.ValueOf(k).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := []rune(v.Type().Field(i).Name)
+		name[0] = unicode.ToLower(name[0])
+		bindings[string(name)] = v.Field(i).Addr().Interface().(*key.Binding)
+	}
+	return bindings
+}
+
+func (k *KeyMap) checkConflicts() error {
+	bound := make(map[string]string)
+	var conflicts []string
+	bindings := k.bindings()
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if inputBindings[name] {
+			continue
+		}
+		for _, key := range bindings[name].Keys() {
+			if other, ok := bound[key]; ok {
+				conflicts = append(conflicts, fmt.Sprintf("%s is bound to both %s and %s", keyName(key), other, name))
+				continue
+			}
+			bound[key] = name
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("conflicting key bindings: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// How keys are shown in the help, e.g. ↑/k
+func helpKeys(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = keyName(k)
+	}
+	return strings.Join(names, "/")
+}
+
+func keyName(k string) string {
+	if name, ok := keyNames[k]; ok {
+		return name
+	}
+	return k
+}
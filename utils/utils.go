@@ -14,6 +14,14 @@ func Min(a, b int) int {
 	return b
 }
 
+func Sum(values []int) int {
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
 func BoolPtr(b bool) *bool          { return &b }
 func IntPtr(i int) *int             { return &i }
 func StringPtr(s string) *string    { return &s }